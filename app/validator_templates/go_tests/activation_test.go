@@ -0,0 +1,29 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestActivation(t *testing.T) {
+    tok, err := GenerateActivationRequest("machine-A")
+    if err != nil {
+        t.Fatal(err)
+    }
+    req, err := ParseActivationRequest(tok)
+    if err != nil || req.MachineID != "machine-A" {
+        t.Fatal(err)
+    }
+    resp, err := IssueActivation(req, LicensePayload{ID: "act", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339)}, testSigner)
+    if err != nil {
+        t.Fatal(err)
+    }
+    key := pubB64(t, &testSigner.PublicKey)
+    if p, err := ValidateActivation(resp, key, WithMachineID("machine-A")); err != nil || p.ID != "act" {
+        t.Fatal(err)
+    }
+    if _, err := ValidateActivation(resp, key, WithMachineID("machine-B")); !errors.Is(err, ErrMachineMismatch) {
+        t.Fatal(err)
+    }
+}
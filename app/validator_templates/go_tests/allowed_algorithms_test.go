@@ -0,0 +1,47 @@
+package main
+
+import (
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestAllowedAlgorithms(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    v15 := func(b []byte) string {
+        h := sha256.Sum256(b)
+        s, _ := rsa.SignPKCS1v15(rand.Reader, testSigner, crypto.SHA256, h[:])
+        return base64.StdEncoding.EncodeToString(s)
+    }
+    pss := func(b []byte) string { return pssSign(t, testSigner, b) }
+    rs := writeEnvelope(t, lic, v15, map[string]any{"alg": "RS256"})
+    pin := WithAllowedAlgorithms(AlgPS256, AlgEdDSA)
+    // RS256 is opt-in.
+    if _, err := Validate(rs, key); !errors.Is(err, ErrAlgorithmNotAllowed) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(rs, key, WithAllowedAlgorithms(AlgRS256)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(rs, key, pin); !errors.Is(err, ErrAlgorithmNotAllowed) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, pss, map[string]any{"alg": "PS256"}), key, pin); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, pss, nil), key, pin); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, pss, nil), key, WithAllowedAlgorithms(AlgEdDSA)); !errors.Is(err, ErrAlgorithmNotAllowed) {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithAllowedAlgorithms("none")); err == nil {
+        t.Fatal("none allowed")
+    }
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestBlake2b(t *testing.T) {
+    seq := make([]byte, 256)
+    for i := range seq {
+        seq[i] = byte(i)
+    }
+    for _, c := range []struct {
+        data []byte
+        size int
+        want string
+    }{
+        {nil, 64, "786a02f742015903c6c6fd852552d272912f4740e15847618a86e217f71f5419d25e1031afee585313896444934eb04b903a685b1448b755d56f701afe9be2ce"},
+        {seq[:3], 20, "147420788d27f83264eb55bad410d304540a21d9"},
+        {seq[:128], 64, "2319e3789c47e2daa5fe807f61bec2a1a6537fa03f19ff32e87eecbfd64b7e0e8ccff439ac333b040f19b0c4ddd11a61e24ac1fe0f10a039806c5dcc0da3d115"},
+        {seq[:129], 64, "f59711d44a031d5f97a9413c065d1e614c417ede998590325f49bad2fd444d3e4418be19aec4e11449ac1a57207898bc57d76a1bcf3566292c20c683a5c4648f"},
+        {make([]byte, 300), 64, "104b2a75c9b7062f1e945d3d366fd4e451957579ea7ef16575578202532b5368ba7c41e39ef11c54258c7104bae569474adc0374a0ba26debe286490807f42d2"},
+    } {
+        if got := hex.EncodeToString(blake2b(c.size, c.data)); got != c.want {
+            t.Fatal(len(c.data), got)
+        }
+    }
+}
+
+func TestArgon2id(t *testing.T) {
+    // Argon2id version 0x13 vectors from the reference implementation's
+    // test suite, for password "password" and salt "somesalt".
+    for _, c := range []struct {
+        params Argon2idParams
+        want   string
+    }{
+        {Argon2idParams{1 << 16, 2, 1}, "09316115d5cf24ed5a15a31a3ba326e5cf32edc24702987c02b6566f61913cf7"},
+        {Argon2idParams{1 << 8, 2, 1}, "9dfeb910e80bad0311fee20f9c0e2b12c17987b4cac90c2ef54d5b3021c68bfe"},
+        {Argon2idParams{1 << 8, 2, 2}, "6d093c501fd5999645e0ea3bf620d7b8be7fd2db59c20d9fff9539da2bf57037"},
+        {Argon2idParams{1 << 16, 1, 1}, "f6a5adc1ba723dddef9b5ac1d464e180fcd9dffc9d1cbf76cca2fed795d9ca98"},
+        {Argon2idParams{1 << 16, 4, 1}, "9025d48e68ef7395cca9079da4c4ec3affb3c8911fe4f86d1a2520856f63172c"},
+    } {
+        if got := hex.EncodeToString(argon2id([]byte("password"), []byte("somesalt"), c.params, 32)); got != c.want {
+            t.Errorf("%v: got %s", c.params, got)
+        }
+    }
+}
+
+func TestArgon2idSeal(t *testing.T) {
+    d, _ := Sign(LicensePayload{ID: "pw", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}, testSigner)
+    b, _ := json.Marshal(d)
+    min := Argon2idParams{MinArgon2idMemory, MinArgon2idIterations, 1}
+    sealed, err := SealLicenseWithArgon2id(b, "hunter2", min)
+    if err != nil || !strings.HasPrefix(sealed, "argon2id$m=19456,t=2,p=1$") {
+        t.Fatal(err, sealed)
+    }
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if r, err := ValidateBytes([]byte(sealed), ring, WithPassphrase("hunter2")); err != nil || r.Payload.ID != "pw" {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes([]byte(sealed), ring, WithPassphrase("wrong")); !errors.Is(err, ErrWrongPassphrase) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes([]byte(sealed), ring); err == nil {
+        t.Fatal("no passphrase accepted")
+    }
+    for _, p := range []Argon2idParams{{1024, 2, 1}, {19456, 1, 1}, {19456, 2, 0}, {1 << 22, 2, 1}} {
+        if _, err := SealLicenseWithArgon2id(b, "x", p); err == nil {
+            t.Fatal("weak accepted", p)
+        }
+    }
+    for _, hdr := range []string{"m=1024,t=2,p=1", "m=19456,t=1,p=1", "m=019456,t=2,p=1", "m=19456,t=2", "m=99999999,t=2,p=1"} {
+        weak := strings.Replace(sealed, "m=19456,t=2,p=1", hdr, 1)
+        if _, err := ValidateBytes([]byte(weak), ring, WithPassphrase("hunter2")); !errors.Is(err, ErrDecryptionFailed) || errors.Is(err, ErrWrongPassphrase) {
+            t.Fatal(hdr, err)
+        }
+    }
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+    "bytes"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestEncryptedAtRest(t *testing.T) {
+    issued, _ := os.ReadFile(writeSigned(t, defaultLicense(time.Now(), 30)))
+    p := filepath.Join(t.TempDir(), "license.lic")
+    if err := SaveEncryptedAtRest(p, issued, "machine-a"); err != nil {
+        t.Fatal(err)
+    }
+    onDisk, _ := os.ReadFile(p)
+    if bytes.Contains(onDisk, issued) {
+        t.Fatal("plaintext on disk")
+    }
+    got, err := LoadEncryptedAtRest(p, "machine-a")
+    if err != nil || !bytes.Equal(got, issued) {
+        t.Fatal(err)
+    }
+    if _, err := LoadEncryptedAtRest(p, "machine-b"); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if _, err := ValidateReport(p, ring, WithEncryptedAtRest(), WithMachineID("machine-a")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithEncryptedAtRest(), WithMachineID("machine-b")); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestAuditJSON(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 30)
+    lic["nonce"] = "secret-nonce"
+    p := writeSigned(t, lic)
+    clk := &fixedClock{issued.AddDate(0, 0, 1).In(time.FixedZone("x", 3600))}
+    var outs [][]byte
+    for range 2 {
+        r, err := ValidateReport(p, ring, WithClock(clk))
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, err := json.Marshal(r)
+        if err != nil {
+            t.Fatal(err)
+        }
+        outs = append(outs, b)
+    }
+    if !bytes.Equal(outs[0], outs[1]) || strings.Contains(string(outs[0]), "secret") || !strings.Contains(string(outs[0]), `"outcome":"valid"`) {
+        t.Fatal(string(outs[0]))
+    }
+    var rec AuditRecord
+    if err := json.Unmarshal(outs[0], &rec); err != nil {
+        t.Fatal(err)
+    }
+    again, _ := json.Marshal(rec)
+    if !bytes.Equal(again, outs[0]) {
+        t.Fatal(string(again), string(outs[0]))
+    }
+    clk.t = issued.AddDate(0, 0, 40)
+    ir, _ := Inspect(p, pubB64(t, &testSigner.PublicKey), WithClock(clk))
+    b, _ := json.Marshal(ir)
+    if !strings.Contains(string(b), `"outcome":"expired"`) || !strings.Contains(string(b), `"reason":"expired"`) {
+        t.Fatal(string(b))
+    }
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestValidateBatch(t *testing.T) {
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)))
+    var paths []string
+    for i := 0; i < 30; i++ {
+        switch i % 3 {
+        case 0:
+            paths = append(paths, writeSigned(t, defaultLicense(time.Now(), 30)))
+        case 1:
+            paths = append(paths, writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)))
+        case 2:
+            paths = append(paths, "/nonexistent/x.lic")
+        }
+    }
+    res := v.ValidateBatch(context.Background(), paths)
+    for i, r := range res {
+        if r.Path != paths[i] {
+            t.Fatal("order")
+        }
+        switch i % 3 {
+        case 0:
+            if r.Err != nil || r.Report == nil {
+                t.Fatal(r.Err)
+            }
+        case 1:
+            if !errors.Is(r.Err, ErrLicenseExpired) {
+                t.Fatal(r.Err)
+            }
+        case 2:
+            var verr *ValidationError
+            if !errors.As(r.Err, &verr) || verr.Reason != ReasonNotFound {
+                t.Fatal(r.Err)
+            }
+        }
+    }
+    if len(v.ValidateBatch(context.Background(), nil)) != 0 {
+        t.Fatal("empty")
+    }
+}
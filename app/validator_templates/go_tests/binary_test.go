@@ -0,0 +1,51 @@
+package main
+
+import (
+    "errors"
+    "reflect"
+    "testing"
+    "time"
+)
+
+func TestBinaryMatchesJSON(t *testing.T) {
+    k, _ := NewTestKeypair(AlgEdDSA)
+    sub := "sub-1"
+    now := time.Now().UTC().Truncate(time.Second)
+    p := LicensePayload{ID: "bin", TenantID: "t", LinkedSubscription: &sub, IssuedAt: now.Format(time.RFC3339), ValidityDays: 30,
+        MachineID: "", MaxSeats: 3, Quotas: map[string]int{"api": 10}, Licensee: Licensee{Name: "Ada"},
+        Features: map[string]Feature{"on": {Enabled: true, Value: "true"}, "off": {Enabled: false, Value: "false"}, "tier": {Enabled: true, Value: "gold"},
+            "addon": {Enabled: true, Value: "true", ExpiresAt: now.AddDate(0, 0, 5)}, "empty": {Enabled: true, Value: ""}},
+        Payload: map[string]any{"plan": "pro", "n": 1.5}}
+    jsonFile, opt := SealTestLicense(t, SignTestLicense(t, k, p))
+    jr, err := ValidateBytes(jsonFile, k.KeyRing(), opt)
+    if err != nil {
+        t.Fatal(err)
+    }
+    bin, err := SignBinary(p, k.Signer)
+    if err != nil {
+        t.Fatal(err)
+    }
+    br, err := ValidateBytes(bin, k.KeyRing())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !reflect.DeepEqual(jr.Payload, br.Payload) || !br.ExpiresAt.Equal(jr.ExpiresAt) {
+        t.Fatalf("%+v\n%+v", jr.Payload, br.Payload)
+    }
+    sealed, _ := SealLicense(bin, make([]byte, 32))
+    if _, err := ValidateBytes([]byte(sealed), k.KeyRing(), WithDecryptionKey(make([]byte, 32))); err != nil {
+        t.Fatal(err)
+    }
+    tampered := append([]byte{}, bin...)
+    tampered[12] ^= 1
+    if _, err := ValidateBytes(tampered, k.KeyRing()); !errors.Is(err, ErrSignatureInvalid) && !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(bin[:len(bin)-3], k.KeyRing()); err == nil {
+        t.Fatal("truncated")
+    }
+    p.IssuedAt = now.Add(time.Millisecond).Format(time.RFC3339Nano)
+    if _, err := SignBinary(p, k.Signer); err == nil {
+        t.Fatal("sub-second")
+    }
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+    "crypto/sha256"
+    "encoding/json"
+    "testing"
+    "time"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+    a, _ := CanonicalJSON([]byte(`{"b": [1, 2.50, {"y":true,"x":null}], "a":"é\n\u0001\"<"}`))
+    b, _ := CanonicalJSON([]byte("{\n \"a\" : \"\\u00e9\\n\\u0001\\\"\\u003c\",\"b\":[1,2.50,{\"x\":null,\"y\":true}]}"))
+    if sha256.Sum256(a) != sha256.Sum256(b) {
+        t.Fatalf("%s != %s", a, b)
+    }
+    if string(a) != `{"a":"é\n\u0001\"<","b":[1,2.50,{"x":null,"y":true}]}` {
+        t.Fatalf("%s", a)
+    }
+    d, err := Sign(LicensePayload{ID: "x", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}, testSigner, WithCanonicalJSON())
+    if err != nil {
+        t.Fatal(err)
+    }
+    var m map[string]any
+    json.Unmarshal(d.License, &m)
+    d.License, _ = json.MarshalIndent(m, "", "   ")
+    buf, _ := json.Marshal(d)
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, buf)), NewKeyRing(&testSigner.PublicKey)); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "math/big"
+    "testing"
+    "time"
+)
+
+func TestCertificateChain(t *testing.T) {
+    caKey, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    ca := &x509.Certificate{SerialNumber: big.NewInt(1), Subject: pkix.Name{CommonName: "ca"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour * 24), IsCA: true, BasicConstraintsValid: true, KeyUsage: x509.KeyUsageCertSign}
+    caDER, _ := x509.CreateCertificate(rand.Reader, ca, ca, &caKey.PublicKey, caKey)
+    caCert, _ := x509.ParseCertificate(caDER)
+    leafTmpl := &x509.Certificate{SerialNumber: big.NewInt(2), Subject: pkix.Name{CommonName: "signer"}, NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour), KeyUsage: x509.KeyUsageDigitalSignature}
+    leafDER, _ := x509.CreateCertificate(rand.Reader, leafTmpl, caCert, &testSigner.PublicKey, caKey)
+    pool := x509.NewCertPool()
+    pool.AddCert(caCert)
+
+    mk := func(x5c string) []byte {
+        d, _ := Sign(LicensePayload{ID: "x", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}, testSigner)
+        d.X5c = []string{x5c}
+        b, _ := json.Marshal(d)
+        return []byte(serverEncrypt(t, b))
+    }
+    empty := NewKeyRing()
+    if _, err := ValidateBytes(mk(base64.StdEncoding.EncodeToString(leafDER)), empty, WithRootCAs(pool)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(base64.StdEncoding.EncodeToString(leafDER)), empty, WithRootCAs(pool), WithClock(&fixedClock{time.Now().Add(2 * time.Hour)})); !errors.Is(err, ErrCertificateExpired) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(base64.StdEncoding.EncodeToString(leafDER)), empty, WithRootCAs(x509.NewCertPool())); !errors.Is(err, ErrCertificateChain) {
+        t.Fatal(err)
+    }
+    other, _ := x509.CreateCertificate(rand.Reader, &x509.Certificate{SerialNumber: big.NewInt(3), NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour), KeyUsage: x509.KeyUsageDigitalSignature}, caCert, &caKey.PublicKey, caKey)
+    if _, err := ValidateBytes(mk(base64.StdEncoding.EncodeToString(other)), empty, WithRootCAs(pool)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
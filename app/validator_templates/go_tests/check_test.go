@@ -0,0 +1,32 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestCheck(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    ctx := context.Background()
+    crl, _ := ParseRevocationList(signedCRL(t, "lic-1"), ring)
+    v, _ := NewValidator(WithKeyRing(ring), WithRevocationList(crl), WithSoftFailures(ReasonExpired))
+    ok, r := v.Check(ctx, writeSigned(t, defaultLicense(time.Now(), 30)))
+    if ok || !errors.Is(r.Err, ErrLicenseRevoked) || r.Payload == nil {
+        t.Fatal(ok, r.Err)
+    }
+    v, _ = NewValidator(WithKeyRing(ring), WithExpiryWarning(72*time.Hour), WithSoftFailures(ReasonExpired))
+    ok, r = v.Check(ctx, writeSigned(t, defaultLicense(time.Now().Add(-29*24*time.Hour), 30)))
+    if !ok || r.Err != nil || !r.ExpiringSoon {
+        t.Fatal(ok, r.Err, r.ExpiringSoon)
+    }
+    ok, r = v.Check(ctx, writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -60), 30)))
+    if !ok || !errors.Is(r.Err, ErrLicenseExpired) || len(r.Failed()) == 0 {
+        t.Fatal(ok, r.Err)
+    }
+    ok, r = v.Check(ctx, "/nonexistent/license.lic")
+    if ok || r.Err == nil {
+        t.Fatal(ok, r)
+    }
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestClockSkew(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    issued := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    exp := issued.AddDate(0, 0, 30)
+    if _, err := Validate(p, key, WithClock(&fixedClock{exp.Add(time.Minute)}), WithClockSkew(time.Minute)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p, key, WithClock(&fixedClock{exp.Add(time.Minute + 1)}), WithClockSkew(time.Minute)); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+    "crypto"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/x509"
+    "crypto/x509/pkix"
+    "encoding/asn1"
+    "encoding/json"
+    "encoding/pem"
+    "errors"
+    "math/big"
+    "testing"
+    "time"
+)
+
+func cmsCert(t *testing.T, name string, parent *x509.Certificate, parentKey crypto.Signer, ca bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+    key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    tmpl := &x509.Certificate{SerialNumber: big.NewInt(time.Now().UnixNano()), Subject: pkix.Name{CommonName: name},
+        NotBefore: time.Now().Add(-time.Hour), NotAfter: time.Now().Add(time.Hour), IsCA: ca, BasicConstraintsValid: true,
+        KeyUsage: x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign}
+    if parent == nil {
+        parent, parentKey = tmpl, key
+    }
+    der, err := x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+    c, _ := x509.ParseCertificate(der)
+    return c, key
+}
+
+type rawSet struct{ Raw asn1.RawContent }
+
+func buildCMS(t *testing.T, content []byte, detached bool, signer *x509.Certificate, key *ecdsa.PrivateKey, certs ...*x509.Certificate) []byte {
+    sum := sha256.Sum256(content)
+    oidData := asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+    ctv, _ := asn1.Marshal(oidData)
+    mdv, _ := asn1.Marshal(sum[:])
+    attrs := []cmsAttribute{
+        {Type: oidCMSContentType, Values: []asn1.RawValue{{FullBytes: ctv}}},
+        {Type: oidCMSMessageDigest, Values: []asn1.RawValue{{FullBytes: mdv}}},
+    }
+    set, _ := asn1.MarshalWithParams(attrs, "set")
+    h := sha256.Sum256(set)
+    sig, _ := ecdsa.SignASN1(rand.Reader, key, h[:])
+    sha := pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}}
+    ias, _ := asn1.Marshal(cmsIssuerAndSerial{Issuer: asn1.RawValue{FullBytes: signer.RawIssuer}, Serial: signer.SerialNumber})
+    signedAttrs := append([]byte{0xA0}, set[1:]...)
+    si, _ := asn1.Marshal(struct {
+        V     int
+        Sid   asn1.RawValue
+        D     pkix.AlgorithmIdentifier
+        Attrs asn1.RawValue
+        S     pkix.AlgorithmIdentifier
+        Sig   []byte
+    }{1, asn1.RawValue{FullBytes: ias}, sha, asn1.RawValue{FullBytes: signedAttrs}, pkix.AlgorithmIdentifier{Algorithm: asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}}, sig})
+    var certBytes []byte
+    for _, c := range certs {
+        certBytes = append(certBytes, c.Raw...)
+    }
+    encap := cmsEncapContent{ContentType: oidData}
+    if !detached {
+        encap.Content = content
+    }
+    sd, err := asn1.Marshal(struct {
+        V       int
+        Digests []pkix.AlgorithmIdentifier `asn1:"set"`
+        Encap   cmsEncapContent
+        Certs   asn1.RawValue
+        Signers asn1.RawValue
+    }{1, []pkix.AlgorithmIdentifier{sha}, encap,
+        asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certBytes},
+        asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: si}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    out, err := asn1.Marshal(struct {
+        T asn1.ObjectIdentifier
+        C asn1.RawValue
+    }{oidCMSSignedData, asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: sd}})
+    if err != nil {
+        t.Fatal(err)
+    }
+    return out
+}
+
+func TestCMS(t *testing.T) {
+    root, rootKey := cmsCert(t, "root", nil, nil, true)
+    leaf, leafKey := cmsCert(t, "leaf", root, rootKey, false)
+    roots := x509.NewCertPool()
+    roots.AddCert(root)
+    content, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    der := buildCMS(t, content, false, leaf, leafKey, leaf)
+    r, err := ValidateBytes(der, NewKeyRing(), WithRootCAs(roots))
+    if err != nil || r.Payload.ID != "lic-1" {
+        t.Fatal(err)
+    }
+    pemData := pem.EncodeToMemory(&pem.Block{Type: "PKCS7", Bytes: der})
+    if _, err := ValidateBytes(append([]byte("\n"), pemData...), NewKeyRing(), WithRootCAs(roots)); err != nil {
+        t.Fatal(err)
+    }
+    detached := buildCMS(t, content, true, leaf, leafKey, leaf)
+    if _, err := ValidateBytes(detached, NewKeyRing(), WithRootCAs(roots)); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(detached, NewKeyRing(), WithRootCAs(roots), WithCMSContent(content)); err != nil {
+        t.Fatal(err)
+    }
+    tampered, _ := json.Marshal(defaultLicense(time.Now(), 3000))
+    if _, err := ValidateBytes(detached, NewKeyRing(), WithRootCAs(roots), WithCMSContent(tampered)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    otherRoot, otherKey := cmsCert(t, "other", nil, nil, true)
+    rogue, rogueKey := cmsCert(t, "leaf", otherRoot, otherKey, false)
+    if _, err := ValidateBytes(buildCMS(t, content, false, rogue, rogueKey, rogue), NewKeyRing(), WithRootCAs(roots)); !errors.Is(err, ErrCertificateChain) {
+        t.Fatal(err)
+    }
+    // Signed by the leaf, but claiming a different certificate as signer.
+    if _, err := ValidateBytes(buildCMS(t, content, false, rogue, leafKey, rogue), NewKeyRing(), WithRootCAs(roots)); !errors.Is(err, ErrCertificateChain) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+// Timing is not asserted; this pins the comparisons to the constant-time helpers' semantics.
+func TestConstantTimeEqual(t *testing.T) {
+    if !constantTimeEqual("abc", "abc") || constantTimeEqual("abc", "abd") || constantTimeEqual("abc", "ab") {
+        t.Fatal("constantTimeEqual")
+    }
+    if !containsConstantTime([]string{"a", "b", "c"}, "a") || containsConstantTime(nil, "") {
+        t.Fatal("containsConstantTime")
+    }
+    s := NewMemoryNonceStore()
+    s.Record("n", time.Time{})
+    if !s.Seen("n") || s.Seen("m") {
+        t.Fatal("nonce")
+    }
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestContextCancel(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        select {
+        case <-r.Context().Done():
+        case <-time.After(5 * time.Second):
+        }
+    }))
+    defer slow.Close()
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+    defer cancel()
+    start := time.Now()
+    _, err := ValidateContext(ctx, p, ring, WithRevocationURL(slow.URL))
+    if !errors.Is(err, context.DeadlineExceeded) || time.Since(start) > 2*time.Second {
+        t.Fatal(err)
+    }
+    c2, cancel2 := context.WithCancel(context.Background())
+    cancel2()
+    if _, err := ValidateContext(c2, p, ring); !errors.Is(err, context.Canceled) {
+        t.Fatal(err)
+    }
+}
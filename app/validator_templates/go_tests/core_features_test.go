@@ -0,0 +1,41 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestCoreFeatures(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 30)
+    lic["core_perpetual"] = true
+    lic["core_features"] = []string{"core"}
+    lic["features"] = map[string]any{"core": true, "premium": true}
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    r, err := ValidateReport(p, ring, WithClock(&fixedClock{issued.AddDate(0, 0, 10)}))
+    if err != nil || r.CoreOnly || !r.HasFeature("core") || !r.HasFeature("premium") {
+        t.Fatal(r, err)
+    }
+    for _, lazy := range []bool{false, true} {
+        opts := []Option{WithClock(&fixedClock{issued.AddDate(1, 0, 0)}), WithGracePeriod(time.Hour), WithExpiryWarning(time.Hour)}
+        if lazy {
+            opts = append(opts, WithLazyEntitlements())
+        }
+        r, err = ValidateReport(p, ring, opts...)
+        if err != nil || !r.CoreOnly || !r.HasFeature("core") || r.HasFeature("premium") || r.ExpiringSoon {
+            t.Fatal(lazy, r, err)
+        }
+        if _, ok := r.Payload.Features["premium"]; ok {
+            t.Fatal("premium kept")
+        }
+        if stateOf(r, err) != StateCoreOnly {
+            t.Fatal(stateOf(r, err))
+        }
+    }
+    delete(lic, "core_perpetual")
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithClock(&fixedClock{issued.AddDate(1, 0, 0)})); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+}
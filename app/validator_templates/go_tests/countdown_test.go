@@ -0,0 +1,42 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestCountdown(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    exp := issued.AddDate(0, 0, 30)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    at := func(now time.Time) *Report {
+        v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(&fixedClock{now}), WithGracePeriod(7*24*time.Hour))
+        r, _ := v.Validate(p)
+        return r
+    }
+    cases := []struct {
+        now  time.Time
+        want string
+    }{
+        {exp.Add(-(12*24*time.Hour + 3*time.Hour + 59*time.Minute)), "expires in 12 days, 3 hours"},
+        {exp.Add(-(24*time.Hour + 5*time.Minute)), "expires in 1 day"},
+        {exp.Add(-(time.Hour + time.Minute)), "expires in 1 hour, 1 minute"},
+        {exp.Add(-30 * time.Second), "expires in less than a minute"},
+        {exp.Add(2 * 24 * time.Hour), "expired 2 days ago, 5 days of grace left"},
+    }
+    for _, c := range cases {
+        if got := at(c.now).ExpiresInHuman(); got != c.want {
+            t.Errorf("%s: %q, want %q", c.now, got, c.want)
+        }
+    }
+    r := &Report{CheckedAt: exp.Add(3*time.Hour + 20*time.Minute), ExpiresAt: exp}
+    if got := r.ExpiresInHuman(); got != "expired 3 hours, 20 minutes ago" {
+        t.Error(got)
+    }
+    if got := (&Report{CheckedAt: exp}).ExpiresInHuman(); got != "never expires" {
+        t.Error(got)
+    }
+    if r.TimeRemaining() != 0 {
+        t.Error(r.TimeRemaining())
+    }
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+    "crypto"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "encoding/json"
+    "io"
+    "path/filepath"
+    "os"
+    "testing"
+    "time"
+)
+
+type stubSigner struct {
+    inner crypto.Signer
+    calls int
+}
+
+func (s *stubSigner) Public() crypto.PublicKey { return s.inner.Public() }
+func (s *stubSigner) Sign(r io.Reader, d []byte, o crypto.SignerOpts) ([]byte, error) {
+    s.calls++
+    return s.inner.Sign(r, d, o)
+}
+
+func TestCryptoSigner(t *testing.T) {
+    payload := LicensePayload{ID: "x", IssuedAt: time.Now().Format(time.RFC3339), ValidityDays: 5}
+    ec, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    for _, tc := range []struct {
+        s   *stubSigner
+        alg string
+    }{{&stubSigner{inner: testSigner}, AlgPS256}, {&stubSigner{inner: testSigner}, AlgRS256}, {&stubSigner{inner: ec}, ""}} {
+        var opts []SignOption
+        if tc.alg != "" {
+            opts = append(opts, WithSigningAlgorithm(tc.alg))
+        }
+        data, err := SignWith(payload, tc.s, opts...)
+        if err != nil || tc.s.calls != 1 {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(data)
+        p := filepath.Join(t.TempDir(), "l")
+        os.WriteFile(p, []byte(serverEncrypt(t, b)), 0o600)
+        if _, err := ValidateReport(p, NewKeyRing(tc.s.Public()), allowRS); err != nil {
+            t.Fatal(tc.alg, err)
+        }
+    }
+    if _, err := SignWith(payload, ec, WithSigningAlgorithm(AlgPS256)); err == nil {
+        t.Fatal()
+    }
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestDefaultPath(t *testing.T) {
+    home := t.TempDir()
+    t.Setenv("HOME", home)
+    t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, "cfg"))
+    t.Setenv(LicenseEnv, "")
+    wd, _ := os.Getwd()
+    cwd := t.TempDir()
+    os.Chdir(cwd)
+    defer os.Chdir(wd)
+    if _, err := DiscoverLicense("acme-test"); !errors.Is(err, ErrNoLicenseFound) {
+        t.Fatal(err)
+    }
+    cfg := filepath.Join(home, "cfg", "acme-test", "license.lic")
+    os.MkdirAll(filepath.Dir(cfg), 0o755)
+    os.WriteFile(cfg, []byte("x"), 0o600)
+    if p, _ := DiscoverLicense("acme-test"); p != cfg {
+        t.Fatal(p)
+    }
+    os.WriteFile("license.lic", []byte("x"), 0o600)
+    if p, _ := DiscoverLicense("acme-test"); p != "license.lic" {
+        t.Fatal(p)
+    }
+    env := filepath.Join(home, "env.lic")
+    os.WriteFile(env, []byte("x"), 0o600)
+    t.Setenv(LicenseEnv, env)
+    if p, _ := DiscoverLicense("acme-test"); p != env {
+        t.Fatal(p)
+    }
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestDelegation(t *testing.T) {
+    delegated, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    now := time.Now().UTC()
+    grant := func(notAfter time.Time, scopes ...string) *SignedDelegation {
+        sd, err := SignDelegation(Delegation{Key: pubB64(t, &delegated.PublicKey), Scopes: scopes, NotBefore: now.Add(-time.Hour).Format(time.RFC3339), NotAfter: notAfter.Format(time.RFC3339)}, testSigner)
+        if err != nil {
+            t.Fatal(err)
+        }
+        return sd
+    }
+    payload := LicensePayload{ID: "d", Scope: "trial", IssuedAt: now.Format(time.RFC3339), ValidityDays: 3}
+    check := func(p LicensePayload, sd *SignedDelegation) error {
+        d, err := SignWith(p, delegated, WithDelegation(sd))
+        if err != nil {
+            t.Fatal(err)
+        }
+        sealed, opt := SealTestLicense(t, d)
+        _, err = ValidateBytes(sealed, ring, opt)
+        return err
+    }
+    if err := check(payload, grant(now.Add(24*time.Hour), "trial", "edu")); err != nil {
+        t.Fatal(err)
+    }
+    if err := check(payload, grant(now.Add(-time.Minute), "trial")); !errors.Is(err, ErrDelegation) {
+        t.Fatal(err)
+    }
+    if err := check(payload, grant(now.Add(time.Hour), "edu")); !errors.Is(err, ErrDelegation) {
+        t.Fatal(err)
+    }
+    forged, _ := SignDelegation(Delegation{Key: pubB64(t, &delegated.PublicKey), Scopes: []string{"trial"}, NotAfter: now.Add(time.Hour).Format(time.RFC3339)}, delegated)
+    if err := check(payload, forged); !errors.Is(err, ErrDelegation) || !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    d, _ := SignWith(payload, delegated)
+    sealed, opt := SealTestLicense(t, d)
+    if _, err := ValidateBytes(sealed, ring, opt); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestDelta(t *testing.T) {
+    k, _ := NewTestKeypair("")
+    issued := time.Now().AddDate(0, 0, -40)
+    base := LicensePayload{ID: "base-1", IssuedAt: issued.Format(time.RFC3339), ValidityDays: 30, Features: map[string]Feature{"a": {}}}
+    baseFile, opt := SealTestLicense(t, SignTestLicense(t, k, base))
+    if _, err := ValidateBytes(baseFile, k.KeyRing(), opt); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    // Both files must open with one key, so seal the delta under the base's key.
+    mk := func(d LicenseDelta) []byte {
+        data, _ := SignDelta(d, k.Signer)
+        return sealWith(t, data, opt)
+    }
+    extend := mk(LicenseDelta{BaseID: "base-1", ExpiresAt: time.Now().AddDate(1, 0, 0).Format(time.RFC3339), Features: map[string]Feature{"b": {}}})
+    r, err := ApplyDelta(baseFile, extend, k.KeyRing(), opt)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !r.HasFeature("a") || !r.HasFeature("b") || r.TimeRemaining() < 300*24*time.Hour {
+        t.Fatal(r.Payload)
+    }
+    other := mk(LicenseDelta{BaseID: "base-2", ExpiresAt: time.Now().AddDate(1, 0, 0).Format(time.RFC3339)})
+    if _, err := ApplyDelta(baseFile, other, k.KeyRing(), opt); !errors.Is(err, ErrDeltaMismatch) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(extend, k.KeyRing(), opt); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal("delta as license", err)
+    }
+    k2, _ := NewTestKeypair("")
+    forged, _ := SignDelta(LicenseDelta{BaseID: "base-1", ExpiresAt: time.Now().AddDate(1, 0, 0).Format(time.RFC3339)}, k2.Signer)
+    if _, err := ApplyDelta(baseFile, sealWith(t, forged, opt), k.KeyRing(), opt); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
+
+// sealWith seals data under the key carried by a WithDecryptionKey option.
+func sealWith(t *testing.T, data LicenseData, opt Option) []byte {
+    var o options
+    opt(&o)
+    b, _ := jsonMarshal(data)
+    key, _ := o.keyProvider.DecryptionKey()
+    s, err := SealLicense(b, key)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return []byte(s)
+}
+
+func jsonMarshal(v any) ([]byte, error) { return json.Marshal(v) }
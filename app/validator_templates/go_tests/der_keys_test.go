@@ -0,0 +1,29 @@
+package main
+
+import (
+    "crypto/x509"
+    "encoding/pem"
+    "os"
+    "path/filepath"
+    "testing"
+)
+
+func TestDERPublicKeys(t *testing.T) {
+    der, _ := x509.MarshalPKIXPublicKey(&testSigner.PublicKey)
+    dir := t.TempDir()
+    for name, data := range map[string][]byte{
+        "der": der,
+        "pem": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+    } {
+        p := filepath.Join(dir, name)
+        os.WriteFile(p, data, 0o600)
+        if _, err := LoadPublicKeyFile(p); err != nil {
+            t.Fatal(name, err)
+        }
+    }
+    p := filepath.Join(dir, "bad")
+    os.WriteFile(p, []byte{0x30, 0x01, 0xff, 0x00}, 0o600)
+    if _, err := LoadPublicKeyFile(p); err == nil {
+        t.Fatal("want error")
+    }
+}
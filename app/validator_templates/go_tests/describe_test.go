@@ -0,0 +1,39 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestDescribe(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["environment"] = "prod"
+    lic["features"] = map[string]any{"sso": true}
+    lic["max_seats"] = 5
+    p := writeEnvelope(t, lic, func(b []byte) string { return pssSign(t, testSigner, b) }, map[string]any{"alg": "PS256"})
+    var out, errb bytes.Buffer
+    if code := runInspect([]string{"-key", pubB64(t, &testSigner.PublicKey), p}, &out, &errb); code != 0 {
+        t.Fatal(code, errb.String(), out.String())
+    }
+    for _, want := range []string{"Status:      VALID", "Environment: prod", "Feature:     sso = true", "Seats:       5"} {
+        if !strings.Contains(out.String(), want) {
+            t.Fatal(want, out.String())
+        }
+    }
+    if strings.Contains(out.String(), masterPrivateKey[:20]) {
+        t.Fatal("leak")
+    }
+    out.Reset()
+    old := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -60), 30))
+    if code := runInspect([]string{"-json", "-key", pubB64(t, &testSigner.PublicKey), old}, &out, &errb); code != 1 {
+        t.Fatal(code)
+    }
+    var s map[string]any
+    json.Unmarshal(out.Bytes(), &s)
+    if s["status"] != "expired" {
+        t.Fatal(out.String())
+    }
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "encoding/base64"
+    "encoding/hex"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestDetachedSignature(t *testing.T) {
+    dir := t.TempDir()
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    sig, _ := base64.StdEncoding.DecodeString(pssSign(t, testSigner, lb))
+    pp := filepath.Join(dir, "lic.json")
+    os.WriteFile(pp, lb, 0o600)
+    key := pubB64(t, &testSigner.PublicKey)
+    for i, enc := range []string{base64.StdEncoding.EncodeToString(sig) + "\n", hex.EncodeToString(sig)} {
+        sp := filepath.Join(dir, "sig"+string(rune('0'+i)))
+        os.WriteFile(sp, []byte(enc), 0o600)
+        if p, err := ValidateDetached(pp, sp, key); err != nil || p.ID != "lic-1" {
+            t.Fatal(err)
+        }
+    }
+    other, _ := json.Marshal(defaultLicense(time.Now(), 31))
+    op := filepath.Join(dir, "other.json")
+    os.WriteFile(op, other, 0o600)
+    if _, err := ValidateDetached(op, filepath.Join(dir, "sig0"), key); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+    "bytes"
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "encoding/json"
+    "testing"
+    "time"
+)
+
+func TestDeterministicSigning(t *testing.T) {
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    ec, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    for _, tc := range []struct {
+        name string
+        sign func(...SignOption) (LicenseData, error)
+    }{
+        {"pss", func(o ...SignOption) (LicenseData, error) { return Sign(payload, testSigner, o...) }},
+        {"ecdsa", func(o ...SignOption) (LicenseData, error) { return SignWith(payload, ec, o...) }},
+    } {
+        a, _ := tc.sign(WithDeterministicSalt())
+        b, _ := tc.sign(WithDeterministicSalt())
+        ja, _ := json.Marshal(a)
+        jb, _ := json.Marshal(b)
+        if !bytes.Equal(ja, jb) {
+            t.Fatal(tc.name, "not deterministic")
+        }
+        c, _ := tc.sign()
+        if c.Signature == a.Signature {
+            t.Fatal(tc.name, "random signing deterministic")
+        }
+        var ring *KeyRing
+        if tc.name == "pss" {
+            ring = NewKeyRing(&testSigner.PublicKey)
+        } else {
+            ring = NewKeyRing(&ec.PublicKey)
+        }
+        if _, err := ValidateBytes([]byte(serverEncrypt(t, ja)), ring); err != nil {
+            t.Fatal(tc.name, err)
+        }
+    }
+}
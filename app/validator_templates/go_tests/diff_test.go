@@ -0,0 +1,46 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestDiff(t *testing.T) {
+    old := LicensePayload{ID: "lic-1", IssuedAt: "2026-01-01T00:00:00Z", ValidityDays: 30, MaxSeats: 10,
+        Features: map[string]Feature{"a": {Enabled: true, Value: "true"}, "gone": {Enabled: true, Value: "true"}},
+        Quotas:   map[string]int{"projects": 5},
+        Products: []Product{{ID: "p", ExpiresAt: "2026-02-01T00:00:00Z"}}}
+    renewed := old.clone()
+    renewed.ExpiresAt = "2026-03-02T00:00:00Z"
+    renewed.ValidityDays = 0
+    renewed.MaxSeats = 25
+    renewed.Serial = 1
+    renewed.Features = map[string]Feature{"a": {Enabled: true, Value: "pro"}, "x": {Enabled: true, Value: "true"}}
+    renewed.Quotas = map[string]int{"projects": 8}
+    renewed.Products = []Product{{ID: "p", ExpiresAt: "2026-03-01T00:00:00Z"}, {ID: "q"}}
+    got := map[string]Change{}
+    for _, c := range Diff(old, renewed) {
+        got[c.Field] = c
+    }
+    if c := got["expiry"]; c.Shift != 30*24*time.Hour || c.String() != "expiry extended by 30 days (2026-01-31T00:00:00Z→2026-03-02T00:00:00Z)" {
+        t.Fatal(c)
+    }
+    if c := got["max_seats"]; c.Delta != 15 || c.String() != "max_seats 10→25 (+15)" {
+        t.Fatal(c)
+    }
+    if got["features.x"].Kind != ChangeAdded || got["features.gone"].Kind != ChangeRemoved || got["features.a"].New != "pro" {
+        t.Fatal(got)
+    }
+    if got["quotas.projects"].Delta != 3 || got["serial"].Kind != ChangeAdded || got["products.q"].Kind != ChangeAdded {
+        t.Fatal(got)
+    }
+    if got["products.p.expires_at"].Shift != 28*24*time.Hour {
+        t.Fatal(got)
+    }
+    if len(got) != 9 {
+        t.Fatal(len(got))
+    }
+    if d := Diff(old, old); len(d) != 0 {
+        t.Fatal(d)
+    }
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestValidateDir(t *testing.T) {
+    dir := t.TempDir()
+    cp := func(src, name string) {
+        b, _ := os.ReadFile(src)
+        os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o700)
+        os.WriteFile(filepath.Join(dir, name), b, 0o600)
+    }
+    cp(writeSigned(t, defaultLicense(time.Now(), 30)), "ok.lic")
+    cp(writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -60), 30)), "old.LIC")
+    cp(writeSigned(t, defaultLicense(time.Now(), 30)), "sub/nested.lic")
+    os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("hello"), 0o600)
+    os.WriteFile(filepath.Join(dir, ".tmp.lic"), []byte("junk"), 0o600)
+    key := pubB64(t, &testSigner.PublicKey)
+    collect := func(recursive bool) map[string]error {
+        ch, err := ValidateDir(context.Background(), dir, key, recursive)
+        if err != nil {
+            t.Fatal(err)
+        }
+        got := map[string]error{}
+        for r := range ch {
+            rel, _ := filepath.Rel(dir, r.Path)
+            got[rel] = r.Err
+        }
+        return got
+    }
+    got := collect(false)
+    if len(got) != 2 || got["ok.lic"] != nil || !errors.Is(got["old.LIC"], ErrLicenseExpired) {
+        t.Fatal(got)
+    }
+    if got := collect(true); len(got) != 3 || got[filepath.Join("sub", "nested.lic")] != nil {
+        t.Fatal(got)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    ch, _ := ValidateDir(ctx, dir, key, true)
+    for range ch {
+    }
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/sha256"
+    "crypto/sha512"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestECDSA(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    for _, c := range []struct {
+        curve elliptic.Curve
+        alg   string
+        wrong string
+    }{{elliptic.P256(), "ES256", "ES384"}, {elliptic.P384(), "ES384", "ES256"}} {
+        k, _ := ecdsa.GenerateKey(c.curve, rand.Reader)
+        sign := func(b []byte) string {
+            var d []byte
+            if c.alg == "ES384" {
+                h := sha512.Sum384(b)
+                d = h[:]
+            } else {
+                h := sha256.Sum256(b)
+                d = h[:]
+            }
+            sig, _ := ecdsa.SignASN1(rand.Reader, k, d)
+            return base64.StdEncoding.EncodeToString(sig)
+        }
+        if _, err := Validate(writeEnvelope(t, lic, sign, map[string]any{"alg": c.alg}), pubB64(t, &k.PublicKey)); err != nil {
+            t.Fatal(c.alg, err)
+        }
+        if _, err := Validate(writeEnvelope(t, lic, sign, map[string]any{"alg": c.wrong}), pubB64(t, &k.PublicKey)); !errors.Is(err, ErrSignatureInvalid) {
+            t.Fatal(c.alg, err)
+        }
+    }
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "crypto/ed25519"
+    "crypto/rand"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestEd25519RoundTrip(t *testing.T) {
+    pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+    sign := func(b []byte) string { return base64.StdEncoding.EncodeToString(ed25519.Sign(priv, b)) }
+    lic := defaultLicense(time.Now(), 30)
+    if _, err := Validate(writeEnvelope(t, lic, sign, map[string]any{"alg": "EdDSA"}), pubB64(t, pub)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, sign, nil), pubB64(t, pub)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, sign, map[string]any{"alg": "PS256"}), pubB64(t, pub)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    bad := func(b []byte) string { s := ed25519.Sign(priv, b); s[0] ^= 1; return base64.StdEncoding.EncodeToString(s) }
+    if _, err := Validate(writeEnvelope(t, lic, bad, nil), pubB64(t, pub)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
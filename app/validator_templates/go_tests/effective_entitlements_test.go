@@ -0,0 +1,38 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestEffectiveEntitlements(t *testing.T) {
+    k, _ := NewTestKeypair("")
+    base := LicensePayload{ID: "base-1", IssuedAt: time.Now().Format(time.RFC3339), ValidityDays: 30, MaxSeats: 5,
+        Features: map[string]Feature{"tier": {Enabled: true, Value: "basic"}, "sso": {Enabled: true, Value: "true"}, "gone": {Enabled: true, Value: "x", ExpiresAt: time.Now().Add(-time.Hour)}},
+        Quotas:   map[string]int{"projects": 3},
+        Products: []Product{{ID: "p", Features: map[string]Feature{"tier": {Enabled: true, Value: "product"}, "export": {Enabled: true, Value: "true"}}}}}
+    baseFile, opt := SealTestLicense(t, SignTestLicense(t, k, base))
+    data, _ := SignDelta(LicenseDelta{BaseID: "base-1", Features: map[string]Feature{"tier": {Enabled: true, Value: "delta"}}, Quotas: map[string]int{"projects": 10}}, k.Signer)
+    delta := sealWith(t, data, opt)
+    for _, lazy := range []bool{false, true} {
+        opts := []Option{opt, WithProduct("p")}
+        if lazy {
+            opts = append(opts, WithLazyEntitlements())
+        }
+        r, err := ApplyDelta(baseFile, delta, k.KeyRing(), opts...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        e := r.EffectiveEntitlements()
+        if e["features.tier"] != "delta" || e["features.export"] != "true" || e["features.sso"] != "true" || e["quotas.projects"] != 10 || e["max_seats"] != 5 || len(e) != 5 {
+            t.Fatal(lazy, e)
+        }
+        if v, _ := r.FeatureValue("tier"); v != "delta" {
+            t.Fatal(v)
+        }
+    }
+    r, _ := ValidateBytes(baseFile, k.KeyRing(), opt)
+    if e := r.EffectiveEntitlements(); e["features.tier"] != "basic" || e["quotas.projects"] != 3 {
+        t.Fatal(e)
+    }
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "testing/fstest"
+    "time"
+)
+
+func TestValidateFS(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateFS(os.DirFS(filepath.Dir(p)), filepath.Base(p), ring); err != nil {
+        t.Fatal(err)
+    }
+    data, _ := os.ReadFile(p)
+    m := fstest.MapFS{"trial.lic": {Data: data}}
+    if _, err := ValidateFS(m, "trial.lic", ring); err != nil {
+        t.Fatal(err)
+    }
+    var verr *ValidationError
+    if _, err := ValidateFS(m, "missing.lic", ring); !errors.As(err, &verr) || verr.Reason != ReasonNotFound {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestEnvelopeSignature(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    ring.AddWithID("k1", &testSigner.PublicKey)
+    ring.AddWithID("other", &testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    file := func(d LicenseData) []byte {
+        b, _ := json.Marshal(d)
+        return []byte(serverEncrypt(t, b))
+    }
+    for _, version := range []int{1, 2} {
+        d, err := Sign(payload, testSigner, WithFormatVersion(version), WithSigningAlgorithm(AlgPS256), WithSigningKeyID("k1"))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if _, err := ValidateBytes(file(d), ring); err != nil {
+            t.Fatal(version, err)
+        }
+        tamperedAlg, tamperedKid := d, d
+        tamperedAlg.Alg = ""
+        tamperedKid.Kid = "other"
+        for _, tampered := range []LicenseData{tamperedAlg, tamperedKid} {
+            _, err := ValidateBytes(file(tampered), ring)
+            if version == 1 && err != nil {
+                t.Fatal(version, err)
+            }
+            if version == 2 && !errors.Is(err, ErrSignatureInvalid) {
+                t.Fatal(version, err)
+            }
+        }
+        if version == 2 {
+            down := d
+            down.Version = 1
+            if _, err := ValidateBytes(file(down), ring); !errors.Is(err, ErrSignatureInvalid) {
+                t.Fatal(err)
+            }
+        }
+    }
+    key := make([]byte, 32)
+    for _, opts := range [][]SignOption{{WithCompression()}, {WithPayloadEncryption(key, "hex"), WithPublicHeader()}} {
+        d, err := Sign(payload, testSigner, append(opts, WithFormatVersion(2))...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(d)
+        var vopts []Option
+        if d.EncryptedPayload == "" {
+            b = file(d)
+        } else {
+            vopts = append(vopts, WithDecryptionKey(key))
+        }
+        if _, err := ValidateBytes(b, ring, vopts...); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if _, err := Sign(payload, testSigner, WithFormatVersion(3)); !errors.Is(err, ErrUnsupportedVersion) {
+        t.Fatal(err)
+    }
+}
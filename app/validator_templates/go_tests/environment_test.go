@@ -0,0 +1,24 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestEnvironment(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    any := writeSigned(t, lic)
+    lic["environment"] = "dev"
+    dev := writeSigned(t, lic)
+    if _, err := ValidateReport(dev, ring, WithEnvironment("dev")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(dev, ring, WithEnvironment("prod")); !errors.Is(err, ErrWrongEnvironment) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(any, ring, WithEnvironment("prod")); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,22 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "io/fs"
+    "path/filepath"
+    "testing"
+)
+
+func TestErrorWrapping(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    _, err := ValidateReport(filepath.Join(t.TempDir(), "missing"), ring)
+    if !errors.Is(err, fs.ErrNotExist) {
+        t.Fatal(err)
+    }
+    _, err = ValidateReport(writeRaw(t, []byte(`{"license": {`)), ring)
+    var syn *json.SyntaxError
+    if !errors.As(err, &syn) || !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestExpiryConsistency(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Now().UTC().Truncate(time.Second)
+    lic := defaultLicense(issued, 30)
+    sign := func(b []byte) string { return pssSign(t, testSigner, b) }
+    good := issued.AddDate(0, 0, 30).Format(time.RFC3339)
+    if _, err := ValidateReport(writeEnvelope(t, lic, sign, map[string]any{"expires_at": good}), ring); err != nil {
+        t.Fatal(err)
+    }
+    bad := issued.AddDate(1, 0, 0).Format(time.RFC3339)
+    p := writeEnvelope(t, lic, sign, map[string]any{"expires_at": bad})
+    if _, err := ValidateReport(p, ring); !errors.Is(err, ErrInconsistentClaims) {
+        t.Fatal(err)
+    }
+    r, _ := Inspect(p, pubB64(t, &testSigner.PublicKey))
+    if f := r.Failed(); len(f) != 1 || f[0].Name != "consistency" {
+        t.Fatal(r.Checks)
+    }
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestExpiryWarning(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    ring := NewKeyRing(&testSigner.PublicKey)
+    exp := issued.AddDate(0, 0, 30)
+    week := 7 * 24 * time.Hour
+    for _, c := range []struct {
+        now  time.Time
+        warn bool
+    }{{exp.Add(-week), false}, {exp.Add(-week + time.Second), true}, {issued, false}} {
+        r, err := ValidateReport(p, ring, WithClock(&fixedClock{c.now}), WithExpiryWarning(week))
+        if err != nil || r.ExpiringSoon != c.warn || r.TimeRemaining() != exp.Sub(c.now) {
+            t.Fatal(c, err, r)
+        }
+    }
+    r, _ := ValidateReport(p, ring, WithClock(&fixedClock{exp.Add(-time.Hour)}))
+    if r.ExpiringSoon {
+        t.Fatal("warned without option")
+    }
+}
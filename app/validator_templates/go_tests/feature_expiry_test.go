@@ -0,0 +1,39 @@
+package main
+
+import (
+    "encoding/json"
+    "testing"
+    "time"
+)
+
+func TestFeatureExpiry(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 365)
+    lic["features"] = map[string]any{
+        "promo": map[string]any{"value": true, "expires_at": "2026-02-01T00:00:00Z"},
+        "tier":  map[string]any{"value": "gold", "expires_at": "2026-03-01T00:00:00Z"},
+        "base":  true,
+    }
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    r, err := ValidateReport(p, ring, WithClock(&fixedClock{issued.AddDate(0, 1, 10)}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if r.HasFeature("promo") || !r.HasFeature("base") || !r.HasFeature("tier") {
+        t.Fatal(r.Payload.Features)
+    }
+    if v, ok := r.FeatureValue("tier"); v != "gold" || !ok {
+        t.Fatal(v)
+    }
+    if _, ok := r.FeatureValue("promo"); ok {
+        t.Fatal("promo declared")
+    }
+    f := r.Payload.Features["tier"]
+    b, _ := json.Marshal(f)
+    var back Feature
+    json.Unmarshal(b, &back)
+    if back != f {
+        t.Fatal(string(b))
+    }
+}
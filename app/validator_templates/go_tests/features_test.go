@@ -0,0 +1,27 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestFeatures(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["features"] = map[string]any{"sso": true, "beta": false, "tier": "gold"}
+    r, err := ValidateReport(writeSigned(t, lic), NewKeyRing(&testSigner.PublicKey))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !r.HasFeature("sso") || r.HasFeature("beta") || r.HasFeature("none") || !r.HasFeature("tier") {
+        t.Fatal("flags")
+    }
+    if v, ok := r.FeatureValue("tier"); v != "gold" || !ok {
+        t.Fatal(v)
+    }
+    if v, ok := r.FeatureValue("beta"); v != "false" || !ok {
+        t.Fatal(v)
+    }
+    if _, ok := r.FeatureValue("none"); ok {
+        t.Fatal()
+    }
+}
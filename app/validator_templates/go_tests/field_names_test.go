@@ -0,0 +1,32 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestFieldNames(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    now := time.Now().UTC().Truncate(time.Second)
+    lic := map[string]any{
+        "id": "alt", "iat": now.Unix(), "exp": now.Add(24 * time.Hour).Format(time.RFC3339),
+        "nbf": now.Add(-time.Hour).Unix(), "entitlements": map[string]any{"sso": true}, "payload": nil,
+    }
+    p := writeSigned(t, lic)
+    names := WithFieldNames(map[string]string{"exp": "expires_at", "nbf": "not_before", "iat": "issued_at", "entitlements": "features"})
+    r, err := ValidateReport(p, ring, names, WithRequiredClaims("features"))
+    if err != nil || !r.HasFeature("sso") || !r.ExpiresAt.Equal(now.Add(24*time.Hour)) || r.Payload.IssuedAt != now.Format(time.RFC3339Nano) {
+        t.Fatal(r, err)
+    }
+    if _, err := ValidateReport(p, ring); err == nil {
+        t.Fatal("native parser accepted alternate names")
+    }
+    lic["expires_at"] = now.Add(time.Hour).Format(time.RFC3339)
+    if _, err := ValidateReport(writeSigned(t, lic), ring, names); !errors.Is(err, ErrInconsistentClaims) {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithFieldNames(map[string]string{"a": "expires_at", "b": "expires_at"})); err == nil {
+        t.Fatal("duplicate target")
+    }
+}
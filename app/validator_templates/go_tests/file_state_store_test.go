@@ -0,0 +1,79 @@
+package main
+
+import (
+    "context"
+    "os"
+    "path/filepath"
+    "strconv"
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestFileStateStoreConcurrentWriters(t *testing.T) {
+    dir := t.TempDir()
+    var wg sync.WaitGroup
+    for w := 0; w < 8; w++ {
+        store, _ := NewFileStateStore(dir)
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for i := 0; i < 100; i++ {
+                store.Update("count", func(old []byte) ([]byte, error) {
+                    n, _ := strconv.Atoi(string(old))
+                    return []byte(strconv.Itoa(n + 1)), nil
+                })
+            }
+        }()
+    }
+    wg.Wait()
+    store, _ := NewFileStateStore(dir)
+    if b, _ := store.Get("count"); string(b) != "800" {
+        t.Fatal(string(b))
+    }
+
+    var paths []string
+    for serial := 0; serial < 20; serial++ {
+        lic := defaultLicense(time.Now(), 30)
+        lic["serial"] = serial
+        paths = append(paths, writeSigned(t, lic))
+    }
+    for w := 0; w < 2; w++ {
+        s, _ := NewFileStateStore(dir)
+        v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithSerialStore(s))
+        wg.Add(1)
+        go func(w int) {
+            defer wg.Done()
+            for i := w; i < len(paths); i += 2 {
+                v.ValidateContext(context.Background(), paths[i])
+            }
+        }(w)
+    }
+    wg.Wait()
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithSerialStore(store))
+    if highest, _, err := v.o.storedSerial("lic-1"); err != nil || highest != 19 {
+        t.Fatal(highest, err)
+    }
+
+    // A stale lock is taken over, and its old owner's unlock leaves the
+    // new owner's lock in place.
+    lock := filepath.Join(dir, "k.lock")
+    staleUnlock, err := lockStateFile(lock)
+    if err != nil {
+        t.Fatal(err)
+    }
+    old := time.Now().Add(-2 * stateLockStale)
+    os.Chtimes(lock, old, old)
+    unlock, err := lockStateFile(lock)
+    if err != nil {
+        t.Fatal(err)
+    }
+    staleUnlock()
+    if _, err := os.Stat(lock); err != nil {
+        t.Fatal("new owner's lock removed:", err)
+    }
+    unlock()
+    if _, err := os.Stat(lock); !os.IsNotExist(err) {
+        t.Fatal("lock left behind:", err)
+    }
+}
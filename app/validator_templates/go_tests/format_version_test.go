@@ -0,0 +1,25 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestFormatVersion(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    sign := func(b []byte) string { return pssSign(t, testSigner, b) }
+    if _, err := ValidateReport(writeSigned(t, lic), ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeEnvelope(t, lic, sign, map[string]any{"version": 1}), ring); err != nil {
+        t.Fatal(err)
+    }
+    lb, _ := json.Marshal(lic)
+    v2, _ := json.Marshal(map[string]any{"version": 3, "payload": json.RawMessage(lb), "sigs": []string{sign(lb)}})
+    if _, err := ValidateReport(writeRaw(t, v2), ring); !errors.Is(err, ErrUnsupportedVersion) {
+        t.Fatal(err)
+    }
+}
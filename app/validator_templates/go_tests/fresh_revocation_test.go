@@ -0,0 +1,50 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestFreshRevocation(t *testing.T) {
+    var revoked atomic.Bool
+    var calls atomic.Int32
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls.Add(1)
+        if r.Header.Get("Cache-Control") != "no-cache" && revoked.Load() {
+            t.Error("missing no-cache")
+        }
+        fmt.Fprintf(w, `{"revoked":%v}`, revoked.Load())
+    }))
+    defer srv.Close()
+    ring := NewKeyRing(&testSigner.PublicKey)
+    cache := NewResultCache(time.Hour)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithResultCache(cache)); err != nil {
+        t.Fatal(err)
+    }
+    revoked.Store(true)
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithResultCache(cache)); err != nil {
+        t.Fatal("expected cached answer", err)
+    }
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithResultCache(cache), WithRequireFreshRevocation()); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    dead := httptest.NewServer(http.NotFoundHandler())
+    dead.Close()
+    if _, err := ValidateReport(p, ring, WithRevocationURL(dead.URL), WithOfflinePolicy(FailOpen), WithRequireFreshRevocation()); !errors.Is(err, ErrRevocationCheck) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithRequireFreshRevocation()); err == nil {
+        t.Fatal("no URL accepted")
+    }
+    n := calls.Load()
+    f := &RevocationFilter{bits: 8, hashes: 1, data: []byte{0}}
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithRevocationFilter(f), WithRequireFreshRevocation()); !errors.Is(err, ErrLicenseRevoked) || calls.Load() != n+1 {
+        t.Fatal(err, calls.Load()-n)
+    }
+}
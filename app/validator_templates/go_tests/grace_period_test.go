@@ -0,0 +1,29 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestGracePeriod(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    ring, _ := ParseKeyRing(key)
+    issued := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    exp := issued.AddDate(0, 0, 30)
+    g := WithGracePeriod(72 * time.Hour)
+    if r, err := ValidateReport(p, ring, g, WithClock(&fixedClock{exp.Add(-time.Hour)})); err != nil || r.InGrace {
+        t.Fatal(err)
+    }
+    r, err := ValidateReport(p, ring, g, WithClock(&fixedClock{exp.Add(time.Hour)}))
+    if !errors.Is(err, ErrInGracePeriod) || r == nil || r.GraceRemaining != 71*time.Hour {
+        t.Fatal(err, r)
+    }
+    if pl, err := Validate(p, key, g, WithClock(&fixedClock{exp.Add(time.Hour)})); pl == nil || !errors.Is(err, ErrInGracePeriod) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, g, WithClock(&fixedClock{exp.Add(72 * time.Hour)})); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+}
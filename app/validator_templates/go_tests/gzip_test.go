@@ -0,0 +1,36 @@
+package main
+
+import (
+    "bytes"
+    "compress/gzip"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestGzipPayload(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    pl := LicensePayload{ID: "z", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3, Features: map[string]Feature{"a": {Enabled: true}}}
+    for _, opts := range [][]SignOption{nil, {WithCompression()}, {WithCompression(), WithCanonicalJSON()}} {
+        d, err := Sign(pl, testSigner, opts...)
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(d)
+        r, err := ValidateBytes([]byte(serverEncrypt(t, b)), ring)
+        if err != nil || r.Payload.ID != "z" || !r.HasFeature("a") {
+            t.Fatal(err)
+        }
+    }
+    var buf bytes.Buffer
+    zw := gzip.NewWriter(&buf)
+    zw.Write(make([]byte, MaxDecompressedSize+10))
+    zw.Close()
+    lic, _ := json.Marshal(base64.StdEncoding.EncodeToString(buf.Bytes()))
+    b, _ := json.Marshal(LicenseData{License: lic, Signature: "AA==", Compressed: true})
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, b)), ring); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+}
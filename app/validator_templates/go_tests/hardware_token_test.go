@@ -0,0 +1,30 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestHardwareToken(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["required_token_serial"] = "YK-123"
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    cache := NewResultCache(time.Hour)
+    if _, err := ValidateReport(p, ring, WithTokenPresenceChecker(StaticToken("YK-123")), WithResultCache(cache)); err != nil {
+        t.Fatal(err)
+    }
+    for _, opts := range [][]Option{
+        {WithTokenPresenceChecker(StaticToken(""))},
+        {WithTokenPresenceChecker(StaticToken("YK-999"))},
+        nil,
+    } {
+        if _, err := ValidateReport(p, ring, append(opts, WithResultCache(cache))...); !errors.Is(err, ErrTokenMissing) {
+            t.Fatal(err)
+        }
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring); err != nil {
+        t.Fatal(err)
+    }
+}
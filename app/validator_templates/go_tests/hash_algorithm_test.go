@@ -0,0 +1,40 @@
+package main
+
+import (
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestHashAlgorithm(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    signWith := func(h crypto.Hash) func([]byte) string {
+        return func(b []byte) string {
+            hh := h.New()
+            hh.Write(b)
+            s, _ := rsa.SignPSS(rand.Reader, testSigner, h, hh.Sum(nil), nil)
+            return base64.StdEncoding.EncodeToString(s)
+        }
+    }
+    if _, err := Validate(writeEnvelope(t, lic, signWith(crypto.SHA512), map[string]any{"alg": "PS512"}), key); err != nil {
+        t.Fatal(err)
+    }
+    p384 := writeEnvelope(t, lic, signWith(crypto.SHA384), nil)
+    if _, err := Validate(p384, key, WithHashAlgorithm(crypto.SHA384)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p384, key); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p384, key, WithHashAlgorithm(crypto.MD5)); !errors.Is(err, ErrUnsupportedAlgorithm) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, signWith(crypto.SHA256), map[string]any{"alg": "XX1"}), key); !errors.Is(err, ErrUnsupportedAlgorithm) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+    "crypto"
+    "crypto/aes"
+    "crypto/cipher"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/sha256"
+    "crypto/x509"
+    "encoding/base64"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+var testSigner, _ = rsa.GenerateKey(rand.Reader, 2048)
+
+func pubB64(t testing.TB, k crypto.PublicKey) string {
+    der, err := x509.MarshalPKIXPublicKey(k)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return base64.StdEncoding.EncodeToString(der)
+}
+
+func serverEncrypt(t testing.TB, plain []byte) string {
+    der, _ := base64.StdEncoding.DecodeString(masterPrivateKey)
+    k, err := x509.ParsePKCS8PrivateKey(der)
+    if err != nil {
+        t.Fatal(err)
+    }
+    pub := &k.(*rsa.PrivateKey).PublicKey
+    aesKey := make([]byte, 32)
+    rand.Read(aesKey)
+    block, _ := aes.NewCipher(aesKey)
+    g, _ := cipher.NewGCM(block)
+    iv := make([]byte, 12)
+    rand.Read(iv)
+    sealed := g.Seal(nil, iv, plain, nil)
+    ct, tag := sealed[:len(sealed)-16], sealed[len(sealed)-16:]
+    blob := append(append(append([]byte{}, iv...), tag...), ct...)
+    wrapped, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, pub, aesKey, nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return base64.StdEncoding.EncodeToString(wrapped) + ":" + base64.StdEncoding.EncodeToString(blob)
+}
+
+func pssSign(t testing.TB, k *rsa.PrivateKey, msg []byte) string {
+    h := sha256.Sum256(msg)
+    s, err := rsa.SignPSS(rand.Reader, k, crypto.SHA256, h[:], nil)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return base64.StdEncoding.EncodeToString(s)
+}
+
+// writeRaw writes an encrypted license file from the full decrypted JSON.
+func writeRaw(t testing.TB, decrypted []byte) string {
+    p := filepath.Join(t.TempDir(), "license.lic")
+    if err := os.WriteFile(p, []byte(serverEncrypt(t, decrypted)), 0o600); err != nil {
+        t.Fatal(err)
+    }
+    return p
+}
+
+func defaultLicense(issued time.Time, days int) map[string]any {
+    return map[string]any{
+        "id": "lic-1", "tenant_id": "ten-1", "linked_subscription": nil,
+        "issued_at": issued.Format(time.RFC3339), "validity_days": days,
+        "payload": map[string]any{"plan": "pro"},
+    }
+}
+
+// writeSigned signs lic with testSigner and writes a license file.
+func writeSigned(t testing.TB, lic map[string]any) string {
+    lb, _ := json.Marshal(lic)
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": pssSign(t, testSigner, lb)})
+    return writeRaw(t, data)
+}
+
+// writeEnvelope writes a license whose outer object is built by extra.
+func writeEnvelope(t testing.TB, lic map[string]any, sign func([]byte) string, extra map[string]any) string {
+    lb, _ := json.Marshal(lic)
+    outer := map[string]any{"license": json.RawMessage(lb), "signature": sign(lb)}
+    for k, v := range extra {
+        outer[k] = v
+    }
+    data, _ := json.Marshal(outer)
+    return writeRaw(t, data)
+}
+
+type fixedClock struct{ t time.Time }
+
+func (c *fixedClock) Now() time.Time { return c.t }
+
+// allowRS also accepts the PKCS#1 v1.5 algorithms, which are opt-in.
+var allowRS = WithAllowedAlgorithms(AlgPS256, AlgPS384, AlgPS512, AlgRS256, AlgRS384, AlgRS512, AlgES256, AlgES384, AlgEdDSA)
@@ -0,0 +1,32 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestHooks(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    var succ, exp, rev int
+    h := WithHooks(Hooks{
+        OnSuccess: func(r Report) { succ++; r.Payload.ID = "mutated"; panic("boom") },
+        OnExpired: func(LicensePayload, time.Time) { exp++ },
+        OnRevoked: func(LicensePayload) { rev++ },
+    })
+    r, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, h)
+    if err != nil || r.Payload.ID != "lic-1" || succ != 1 || exp+rev != 0 {
+        t.Fatal(err, succ, exp, rev)
+    }
+    ValidateReport(writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)), ring, h)
+    if exp != 1 || succ != 1 || rev != 0 {
+        t.Fatal(succ, exp, rev)
+    }
+    crl, _ := ParseRevocationList(signedCRL(t, "lic-1"), ring)
+    ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, h, WithRevocationList(crl))
+    if exp != 1 || succ != 1 || rev != 1 {
+        t.Fatal(succ, exp, rev)
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, WithHooks(Hooks{})); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "testing"
+    "time"
+)
+
+func TestHosts(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["allowed_hosts"] = []string{"db1.corp.local", "*.Example.com"}
+    p := writeSigned(t, lic)
+    for h, ok := range map[string]bool{"db1.corp.local": true, "DB1.corp.local.": true, "api.example.com": true, "example.com": false, "a.b.example.com": false, "db2.corp.local": false} {
+        _, err := ValidateReport(p, ring, WithHostname(h))
+        if ok && err != nil || !ok && !errors.Is(err, ErrHostNotAllowed) {
+            t.Fatal(h, err)
+        }
+    }
+    if _, err := ValidateReport(p, ring); !errors.Is(err, ErrHostNotAllowed) {
+        t.Fatal("default hostname", err)
+    }
+    host, _ := os.Hostname()
+    lic["allowed_hosts"] = []string{host}
+    if _, err := ValidateReport(writeSigned(t, lic), ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, WithHostname("x")); err != nil {
+        t.Fatal(err)
+    }
+}
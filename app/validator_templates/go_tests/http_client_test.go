@@ -0,0 +1,27 @@
+package main
+
+import (
+    "crypto/tls"
+    "crypto/x509"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestHTTPClient(t *testing.T) {
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"revoked":true}`)) }))
+    defer srv.Close()
+    pool := x509.NewCertPool()
+    pool.AddCert(srv.Certificate())
+    client := &http.Client{Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool}}}
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithHTTPClient(client)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL), WithRevocationFailClosed()); !errors.Is(err, ErrRevocationCheck) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestHybridEnvelopeRoundTrip(t *testing.T) {
+    install, _ := rsa.GenerateKey(rand.Reader, 2048)
+    other, _ := rsa.GenerateKey(rand.Reader, 2048)
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": pssSign(t, testSigner, lb)})
+    env, err := EncryptEnvelope(data, &install.PublicKey)
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := filepath.Join(t.TempDir(), "l")
+    os.WriteFile(p, []byte(env), 0o600)
+    key := pubB64(t, &testSigner.PublicKey)
+    if _, err := Validate(p, key, WithPrivateKey(install)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p, key, WithPrivateKey(other)); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p, key); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+}
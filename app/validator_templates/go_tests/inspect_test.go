@@ -0,0 +1,32 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestInspect(t *testing.T) {
+    lic := defaultLicense(time.Now().AddDate(0, 0, -40), 30)
+    lic["machine_id"] = "other"
+    lic["nonce"] = "n"
+    p := writeSigned(t, lic)
+    store := NewMemoryNonceStore()
+    r, err := Inspect(p, pubB64(t, &testSigner.PublicKey), WithMachineID("me"), WithNonceStore(store))
+    if err != nil {
+        t.Fatal(err)
+    }
+    failed := map[string]bool{}
+    for _, c := range r.Failed() {
+        failed[c.Name] = true
+    }
+    if len(failed) != 2 || !failed["machine"] || !failed["expiry"] {
+        t.Fatal(r.Checks)
+    }
+    if store.Seen("n") {
+        t.Fatal("nonce consumed")
+    }
+    r, _ = Inspect(writeRaw(t, []byte("{")), pubB64(t, &testSigner.PublicKey))
+    if len(r.Failed()) != 1 || r.Checks[0].Name != "decode" {
+        t.Fatal(r.Checks)
+    }
+}
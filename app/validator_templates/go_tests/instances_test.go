@@ -0,0 +1,66 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestInstanceLimit(t *testing.T) {
+    dir := t.TempDir()
+    lic := defaultLicense(time.Now(), 30)
+    lic["max_instances"] = 2
+    r, err := ValidateReport(writeSigned(t, lic), NewKeyRing(&testSigner.PublicKey))
+    if err != nil {
+        t.Fatal(err)
+    }
+    a, err := r.AcquireInstance(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    b, err := r.AcquireInstance(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, err := r.AcquireInstance(dir); !errors.Is(err, ErrInstanceLimit) {
+        t.Fatal(err)
+    }
+    a.Release()
+    a.Release()
+    c, err := r.AcquireInstance(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    // Simulate a crashed holder of b's slot.
+    b.Release()
+    os.WriteFile(b.path, []byte("999999\n"), 0o600)
+    old := time.Now().Add(-time.Hour)
+    os.Chtimes(b.path, old, old)
+    d, err := r.AcquireInstance(dir)
+    if err != nil {
+        t.Fatal("stale not reclaimed", err)
+    }
+    if filepath.Base(d.path) != filepath.Base(b.path) {
+        t.Fatal(d.path)
+    }
+    // A holder paused past the stale age leaves the slot taken from it alone.
+    os.Chtimes(c.path, old, old)
+    e, err := r.AcquireInstance(dir)
+    if err != nil {
+        t.Fatal("stale not reclaimed", err)
+    }
+    if e.path != c.path {
+        t.Fatal(e.path)
+    }
+    c.Release()
+    if !lockHeldBy(e.path, e.token) {
+        t.Fatal("released the new holder's slot")
+    }
+    e.Release()
+    d.Release()
+    if n, _ := os.ReadDir(dir); len(n) != 0 {
+        t.Fatal(n)
+    }
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestUnaryGuard(t *testing.T) {
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)))
+    good := writeSigned(t, defaultLicense(time.Now(), 30))
+    m := map[string][]string{"/svc/Pro": {"pro"}}
+    g := RPCGuard(v, good, m)
+    if err := g(context.Background(), "/svc/Basic"); GRPCCode(err) != GRPCOK {
+        t.Fatal(err)
+    }
+    if err := g(context.Background(), "/svc/Pro"); GRPCCode(err) != GRPCPermissionDenied {
+        t.Fatal(err)
+    }
+    exp := RPCGuard(v, writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)), m)
+    if err := exp(context.Background(), "/svc/Basic"); GRPCCode(err) != GRPCPermissionDenied {
+        t.Fatal(err)
+    }
+    bad := RPCGuard(v, writeRaw(t, []byte("{")), m)
+    if err := bad(context.Background(), "/svc/Basic"); GRPCCode(err) != GRPCFailedPrecondition {
+        t.Fatal(err)
+    }
+    ctx, cancel := context.WithCancel(context.Background())
+    cancel()
+    if err := g(ctx, "/svc/Basic"); GRPCCode(err) != GRPCCanceled {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestIssuerAudience(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["iss"] = "sigma"
+    lic["aud"] = []string{"tenant-a", "tenant-c"}
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithExpectedIssuer("sigma"), WithExpectedAudience("tenant-c")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithExpectedAudience("tenant-b")); !errors.Is(err, ErrAudienceMismatch) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithExpectedIssuer("other")); !errors.Is(err, ErrIssuerMismatch) {
+        t.Fatal(err)
+    }
+    lic["aud"] = "tenant-b"
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithExpectedAudience("tenant-b")); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "crypto/sha256"
+    "encoding/base64"
+    "errors"
+    "fmt"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestJWKS(t *testing.T) {
+    ec, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    b := base64.RawURLEncoding.EncodeToString
+    var hits atomic.Int32
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        hits.Add(1)
+        fmt.Fprintf(w, `{"keys":[{"kty":"RSA","kid":"r1","n":%q,"e":%q},{"kty":"EC","kid":"e1","crv":"P-256","x":%q,"y":%q}]}`,
+            b(testSigner.N.Bytes()), b(big.NewInt(int64(testSigner.E)).Bytes()), b(ec.X.FillBytes(make([]byte, 32))), b(ec.Y.FillBytes(make([]byte, 32))))
+    }))
+    defer srv.Close()
+    src := NewJWKS(srv.URL, time.Minute)
+    lic := defaultLicense(time.Now(), 30)
+    rs := func(bb []byte) string { return pssSign(t, testSigner, bb) }
+    es := func(bb []byte) string { h := sha256.Sum256(bb); s, _ := ecdsa.SignASN1(rand.Reader, ec, h[:]); return base64.StdEncoding.EncodeToString(s) }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, rs, map[string]any{"kid": "r1"}), nil, WithKeySource(src), WithHTTPClient(srv.Client())); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, es, map[string]any{"kid": "e1"}), nil, WithKeySource(src), WithHTTPClient(srv.Client())); err != nil {
+        t.Fatal(err)
+    }
+    if hits.Load() != 1 {
+        t.Fatal("hits", hits.Load())
+    }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, es, map[string]any{"kid": "zz"}), nil, WithKeySource(src), WithHTTPClient(srv.Client())); !errors.Is(err, ErrUnknownKeyID) {
+        t.Fatal(err)
+    }
+    if hits.Load() != 2 {
+        t.Fatal("hits", hits.Load())
+    }
+    // A key set served over plain http is never fetched.
+    plain := httptest.NewServer(srv.Config.Handler)
+    defer plain.Close()
+    before := hits.Load()
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, rs, map[string]any{"kid": "r1"}), nil, WithKeySource(NewJWKS(plain.URL, time.Minute))); !errors.Is(err, ErrKeyFetchFailed) || hits.Load() != before {
+        t.Fatal(err, hits.Load())
+    }
+    srv.Close()
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, es, map[string]any{"kid": "zz"}), nil, WithKeySource(src), WithHTTPClient(srv.Client())); !errors.Is(err, ErrKeyFetchFailed) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+    "crypto"
+    "crypto/ed25519"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func jwt(t *testing.T, header, claims map[string]any, sign func([]byte) []byte) string {
+    h, _ := json.Marshal(header)
+    c, _ := json.Marshal(claims)
+    e := base64.RawURLEncoding.EncodeToString
+    in := e(h) + "." + e(c)
+    return in + "." + e(sign([]byte(in)))
+}
+
+func writeFile(t *testing.T, s string) string {
+    p := filepath.Join(t.TempDir(), "l.jwt")
+    os.WriteFile(p, []byte(s), 0o600)
+    return p
+}
+
+func TestJWT(t *testing.T) {
+    edpub, edpriv, _ := ed25519.GenerateKey(rand.Reader)
+    ring := NewKeyRing(&testSigner.PublicKey, edpub)
+    rs := func(b []byte) []byte {
+        h := sha256.Sum256(b)
+        s, _ := rsa.SignPKCS1v15(rand.Reader, testSigner, crypto.SHA256, h[:])
+        return s
+    }
+    claims := map[string]any{"jti": "j1", "exp": time.Now().Add(time.Hour).Unix(), "iat": time.Now().Unix(), "features": map[string]any{"a": true}}
+    r, err := ValidateReport(writeFile(t, jwt(t, map[string]any{"alg": "RS256"}, claims, rs)), ring, allowRS)
+    if err != nil || r.Payload.ID != "j1" || !r.HasFeature("a") {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeFile(t, jwt(t, map[string]any{"alg": "EdDSA"}, claims, func(b []byte) []byte { return ed25519.Sign(edpriv, b) })), ring); err != nil {
+        t.Fatal(err)
+    }
+    claims["exp"] = time.Now().Add(-time.Hour).Unix()
+    if _, err := ValidateReport(writeFile(t, jwt(t, map[string]any{"alg": "RS256"}, claims, rs)), ring, allowRS); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeFile(t, jwt(t, map[string]any{"alg": "none"}, claims, func([]byte) []byte { return []byte("x") })), ring); !errors.Is(err, ErrUnsupportedAlgorithm) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func BenchmarkValidate(b *testing.B) {
+    k := pubB64(b, &testSigner.PublicKey)
+    p := writeSigned(b, defaultLicense(time.Now(), 30))
+    for i := 0; i < b.N; i++ {
+        if _, err := Validate(p, k); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
+
+func BenchmarkValidatorReused(b *testing.B) {
+    v, err := NewValidator(WithPublicKeys(pubB64(b, &testSigner.PublicKey)))
+    if err != nil {
+        b.Fatal(err)
+    }
+    p := writeSigned(b, defaultLicense(time.Now(), 30))
+    for i := 0; i < b.N; i++ {
+        if _, err := v.Validate(p); err != nil {
+            b.Fatal(err)
+        }
+    }
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/x509"
+    "encoding/pem"
+    "errors"
+    "strings"
+    "testing"
+)
+
+func TestPublicKeyEnv(t *testing.T) {
+    der, _ := x509.MarshalPKIXPublicKey(&testSigner.PublicKey)
+    p := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+    t.Setenv("SIGMA_PERMIT_PUBKEY", "  "+strings.ReplaceAll(p, "\n", `\n`)+"  ")
+    k, err := LoadPublicKeyEnv("")
+    if err != nil || !k.(*rsa.PublicKey).Equal(&testSigner.PublicKey) {
+        t.Fatal(err)
+    }
+    if _, err := LoadPublicKeyEnv("NOPE_UNSET_X"); !errors.Is(err, ErrUnsupportedKey) {
+        t.Fatal(err)
+    }
+    priv, _ := rsa.GenerateKey(rand.Reader, 1024)
+    t.Setenv("X_PRIV", string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(priv)})))
+    _, err = LoadPublicKeyEnv("X_PRIV")
+    if !errors.Is(err, ErrUnsupportedKey) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+    "crypto/x509"
+    "encoding/pem"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestPublicKeyFile(t *testing.T) {
+    d := t.TempDir()
+    der, _ := x509.MarshalPKIXPublicKey(&testSigner.PublicKey)
+    good := filepath.Join(d, "k.pem")
+    os.WriteFile(good, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600)
+    b64 := filepath.Join(d, "k.b64")
+    os.WriteFile(b64, []byte(pubB64(t, &testSigner.PublicKey)+"\n"), 0o600)
+    empty := filepath.Join(d, "e")
+    os.WriteFile(empty, nil, 0o600)
+    garbage := filepath.Join(d, "g")
+    os.WriteFile(garbage, []byte("hello world!"), 0o600)
+    lic := writeSigned(t, defaultLicense(time.Now(), 30))
+    for _, k := range []string{good, b64} {
+        if _, err := ValidateWithKeyFile(lic, k); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if _, err := ValidateWithKeyFile(lic, filepath.Join(d, "none")); !errors.Is(err, os.ErrNotExist) || !errors.Is(err, ErrUnsupportedKey) {
+        t.Fatal(err)
+    }
+    for _, k := range []string{empty, garbage} {
+        _, err := ValidateWithKeyFile(lic, k)
+        if !errors.Is(err, ErrUnsupportedKey) {
+            t.Fatal(err)
+        }
+    }
+}
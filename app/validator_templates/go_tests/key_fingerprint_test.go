@@ -0,0 +1,39 @@
+package main
+
+import (
+    "crypto/ed25519"
+    "crypto/rand"
+    "crypto/x509"
+    "encoding/pem"
+    "strings"
+    "testing"
+)
+
+func TestPublicKeyFingerprint(t *testing.T) {
+    der, _ := x509.MarshalPKIXPublicKey(&testSigner.PublicKey)
+    pemKey := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+    var fps []string
+    for _, in := range []any{&testSigner.PublicKey, pubB64(t, &testSigner.PublicKey), pemKey, string(pemKey), der} {
+        fp, err := PublicKeyFingerprint(in)
+        if err != nil {
+            t.Fatal(err)
+        }
+        fps = append(fps, fp)
+    }
+    for _, fp := range fps {
+        if fp != fps[0] || !strings.HasPrefix(fp, "SHA256:") || len(fp) != 50 {
+            t.Fatal(fps)
+        }
+    }
+    pub, _, _ := ed25519.GenerateKey(rand.Reader)
+    other, _ := PublicKeyFingerprint(pub)
+    if other == fps[0] || other == "" {
+        t.Fatal(other)
+    }
+    if _, err := PublicKeyFingerprint("nope"); err == nil {
+        t.Fatal("accepted garbage")
+    }
+    if _, err := PublicKeyFingerprint(42); err == nil {
+        t.Fatal("accepted int")
+    }
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestKeyID(t *testing.T) {
+    old, _ := rsa.GenerateKey(rand.Reader, 2048)
+    ring := NewKeyRingFromMap(map[string]crypto.PublicKey{"old": &old.PublicKey, "new": &testSigner.PublicKey})
+    sign := func(b []byte) string { return pssSign(t, testSigner, b) }
+    lic := defaultLicense(time.Now(), 30)
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, sign, map[string]any{"kid": "new"}), ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, sign, map[string]any{"kid": "old"}), ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, sign, map[string]any{"kid": "x"}), ring); !errors.Is(err, ErrUnknownKeyID) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateKeyRing(writeEnvelope(t, lic, sign, nil), ring); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+    "bytes"
+    "crypto/rand"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+type stubProvider struct {
+    key    []byte
+    calls  int
+    handed [][]byte
+    err    error
+}
+
+func (p *stubProvider) DecryptionKey() ([]byte, error) {
+    p.calls++
+    k := bytes.Clone(p.key)
+    p.handed = append(p.handed, k)
+    return k, p.err
+}
+
+func TestKeyProvider(t *testing.T) {
+    key := make([]byte, 32)
+    rand.Read(key)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    d, _ := Sign(payload, testSigner, WithPayloadEncryption(key, "base64"))
+    b, _ := json.Marshal(d)
+    p := &stubProvider{key: key}
+    if _, err := ValidateBytes(b, ring, WithKeyProvider(p)); err != nil || p.calls != 1 {
+        t.Fatal(err, p.calls)
+    }
+    if !bytes.Equal(p.handed[0], make([]byte, 32)) {
+        t.Fatal("key not cleared")
+    }
+    wrong := &stubProvider{key: make([]byte, 32)}
+    if _, err := ValidateBytes(b, ring, WithKeyProvider(wrong)); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    failing := &stubProvider{err: errors.New("keychain locked")}
+    if _, err := ValidateBytes(b, ring, WithKeyProvider(failing)); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    // Whole-file sealing goes through the provider too.
+    sealed, _ := SealLicense(mustJSON(t, d0(t, payload)), key)
+    p2 := &stubProvider{key: key}
+    if _, err := ValidateBytes([]byte(sealed), ring, WithKeyProvider(p2)); err != nil || p2.calls != 1 {
+        t.Fatal(err)
+    }
+}
+
+func d0(t *testing.T, payload LicensePayload) LicenseData {
+    d, err := Sign(payload, testSigner)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return d
+}
+
+func mustJSON(t *testing.T, v any) []byte {
+    b, err := json.Marshal(v)
+    if err != nil {
+        t.Fatal(err)
+    }
+    return b
+}
@@ -0,0 +1,27 @@
+package main
+
+import (
+    "crypto/ed25519"
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestKeyRingRotation(t *testing.T) {
+    old, _ := rsa.GenerateKey(rand.Reader, 2048)
+    edpub, _, _ := ed25519.GenerateKey(rand.Reader)
+    ring, err := ParseKeyRing(pubB64(t, &old.PublicKey), pubB64(t, edpub), pubB64(t, &testSigner.PublicKey))
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateKeyRing(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    _, err = ValidateKeyRing(p, NewKeyRing(&old.PublicKey, edpub))
+    if !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
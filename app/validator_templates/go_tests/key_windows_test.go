@@ -0,0 +1,29 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestKeyWindows(t *testing.T) {
+    newKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+    cut := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+    ring := NewKeyRing()
+    ring.AddEntry("", KeyEntry{Key: &testSigner.PublicKey, NotAfter: cut})
+    ring.AddEntry("new", KeyEntry{Key: &newKey.PublicKey, NotBefore: cut.AddDate(0, -1, 0)})
+    p := writeSigned(t, defaultLicense(cut.AddDate(0, -2, 0), 365))
+    if _, err := ValidateReport(p, ring, WithClock(&fixedClock{cut.Add(-time.Hour)})); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithClock(&fixedClock{cut})); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    ring2 := NewKeyRing()
+    ring2.AddEntry("", KeyEntry{Key: &testSigner.PublicKey, NotAfter: cut})
+    if _, err := ValidateReport(p, ring2, WithClock(&fixedClock{cut})); !errors.Is(err, ErrKeyNotActive) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "testing"
+    "time"
+)
+
+func bigClaims(n int) map[string]any {
+    lic := defaultLicense(time.Now(), 30)
+    features := make(map[string]any, n)
+    quotas := make(map[string]any, n)
+    for i := range n {
+        features[fmt.Sprintf("res-%d", i)] = i%2 == 0
+        quotas[fmt.Sprintf("res-%d", i)] = i
+    }
+    features["addon"] = map[string]any{"value": "gold", "expires_at": time.Now().Add(time.Hour).Format(time.RFC3339)}
+    lic["features"], lic["quotas"] = features, quotas
+    return lic
+}
+
+func TestLazyEntitlements(t *testing.T) {
+    lic := bigClaims(2000)
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    full, err := ValidateReport(p, ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    lazy, err := ValidateReport(p, ring, WithLazyEntitlements())
+    if err != nil {
+        t.Fatal(err)
+    }
+    if lazy.Payload.Features != nil || lazy.Payload.Quotas != nil {
+        t.Fatal("decoded")
+    }
+    for _, name := range []string{"res-0", "res-1", "res-1999", "addon", "missing"} {
+        if full.HasFeature(name) != lazy.HasFeature(name) {
+            t.Fatal(name)
+        }
+        a, aok := full.FeatureValue(name)
+        b, bok := lazy.FeatureValue(name)
+        c, cok := full.Quota(name)
+        d, dok := lazy.Quota(name)
+        if a != b || aok != bok || c != d || cok != dok {
+            t.Fatal(name, a, b, c, d)
+        }
+    }
+    if !lazy.WithinQuota("res-5", 5) || lazy.WithinQuota("res-5", 6) {
+        t.Fatal("quota")
+    }
+    strict, err := ValidateReport(p, ring, WithLazyEntitlements(), WithStrictFields())
+    if err != nil || strict.Payload.Features == nil {
+        t.Fatal(err)
+    }
+    // Duplicates and nulls resolve like encoding/json.
+    claims := []byte(`{"features":{"a":true,"b":true},"x":[1,{"features":{}}],"features":{"a":false},"quotas":null}`)
+    var ref LicensePayload
+    json.Unmarshal(claims, &ref)
+    for _, name := range []string{"a", "b", "c"} {
+        f, ok, err := LookupFeature(claims, name)
+        rf, rok := ref.Features[name]
+        if err != nil || ok != rok || f != rf {
+            t.Fatal(name, f, ok, err)
+        }
+    }
+    if _, ok, err := LookupQuota([]byte(`{"quotas":{"a":1},"quotas":null}`), "a"); ok || err != nil {
+        t.Fatal(ok, err)
+    }
+    if _, _, err := LookupFeature([]byte(`{"features":[1]}`), "a"); err == nil {
+        t.Fatal("array accepted")
+    }
+    if _, _, err := LookupFeature([]byte(`{"features":{"a":`), "a"); err == nil {
+        t.Fatal("truncated accepted")
+    }
+}
+
+func BenchmarkLazyEntitlementLookup(b *testing.B) {
+    claims, _ := json.Marshal(bigClaims(20000))
+    b.Run("unmarshal", func(b *testing.B) {
+        for b.Loop() {
+            var p LicensePayload
+            json.Unmarshal(claims, &p)
+            _ = p.Features["res-19999"]
+        }
+    })
+    b.Run("lookup", func(b *testing.B) {
+        for b.Loop() {
+            LookupFeature(claims, "res-19999")
+        }
+    })
+    o := newOptions(nil)
+    b.Run("lazy-decode", func(b *testing.B) {
+        for b.Loop() {
+            o.decodePayload(claims, true)
+        }
+    })
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestLazyKeysConcurrent(t *testing.T) {
+    var loads atomic.Int32
+    v, err := NewValidator(WithKeyLoader(func(ctx context.Context) (*KeyRing, error) {
+        loads.Add(1)
+        time.Sleep(10 * time.Millisecond)
+        return NewKeyRing(&testSigner.PublicKey), nil
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    var wg sync.WaitGroup
+    errs := make(chan error, 64)
+    for range 64 {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := v.Validate(p); err != nil {
+                errs <- err
+            }
+        }()
+    }
+    wg.Wait()
+    close(errs)
+    for err := range errs {
+        t.Fatal(err)
+    }
+    if n := loads.Load(); n != 1 {
+        t.Fatal("loads", n)
+    }
+
+    fail := true
+    v, _ = NewValidator(WithKeyLoader(func(ctx context.Context) (*KeyRing, error) {
+        if fail {
+            fail = false
+            return nil, errors.New("unavailable")
+        }
+        return NewKeyRing(&testSigner.PublicKey), nil
+    }))
+    if _, err := v.Validate(p); !errors.Is(err, ErrKeyFetchFailed) {
+        t.Fatal(err)
+    }
+    if _, err := v.Validate(p); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func TestLazyKeysWaiterCancelled(t *testing.T) {
+    release := make(chan struct{})
+    started := make(chan struct{})
+    v, err := NewValidator(WithKeyLoader(func(ctx context.Context) (*KeyRing, error) {
+        close(started)
+        <-release
+        return NewKeyRing(&testSigner.PublicKey), nil
+    }))
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    first := make(chan error, 1)
+    go func() {
+        _, err := v.Validate(p)
+        first <- err
+    }()
+    <-started
+
+    // A caller waiting on a hung loader gives up when its context does.
+    ctx, cancel := context.WithCancel(context.Background())
+    time.AfterFunc(20*time.Millisecond, cancel)
+    start := time.Now()
+    if _, err := v.ValidateContext(ctx, p); !errors.Is(err, context.Canceled) {
+        t.Fatal(err)
+    }
+    if d := time.Since(start); d > time.Second {
+        t.Fatal("waited", d)
+    }
+    close(release)
+    if err := <-first; err != nil {
+        t.Fatal(err)
+    }
+}
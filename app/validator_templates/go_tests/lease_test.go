@@ -0,0 +1,98 @@
+package main
+
+import (
+    "context"
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "testing"
+    "time"
+)
+
+type leaseServer struct {
+    mu     sync.Mutex
+    seats  int
+    ttl    time.Duration
+    leases map[string]time.Time
+    n      int
+}
+
+func (s *leaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+    s.mu.Lock()
+    defer s.mu.Unlock()
+    var body map[string]string
+    json.NewDecoder(r.Body).Decode(&body)
+    now := time.Now()
+    for k, e := range s.leases {
+        if !now.Before(e) {
+            delete(s.leases, k)
+        }
+    }
+    switch r.URL.Path {
+    case "/checkout":
+        if len(s.leases) >= s.seats {
+            w.WriteHeader(http.StatusConflict)
+            return
+        }
+        s.n++
+        tok := string(rune('a' + s.n))
+        s.leases[tok] = now.Add(s.ttl)
+        json.NewEncoder(w).Encode(map[string]any{"token": tok, "expires_at": s.leases[tok]})
+    case "/renew":
+        if _, ok := s.leases[body["token"]]; !ok {
+            w.WriteHeader(http.StatusNotFound)
+            return
+        }
+        s.leases[body["token"]] = now.Add(s.ttl)
+        json.NewEncoder(w).Encode(map[string]any{"token": body["token"], "expires_at": s.leases[body["token"]]})
+    case "/checkin":
+        delete(s.leases, body["token"])
+    }
+}
+
+func TestLease(t *testing.T) {
+    ls := &leaseServer{seats: 1, ttl: 2 * time.Second, leases: map[string]time.Time{}}
+    srv := httptest.NewServer(ls)
+    defer srv.Close()
+    ctx := context.Background()
+    l, err := Checkout(ctx, srv.URL, "lic-1")
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Checkout(ctx, srv.URL, "lic-1"); !errors.Is(err, ErrPoolExhausted) {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if _, err := ValidateReport(p, ring, WithLease(l)); err != nil {
+        t.Fatal(err)
+    }
+    kctx, stop := context.WithCancel(ctx)
+    done := make(chan error)
+    go func() { done <- l.KeepAlive(kctx) }()
+    time.Sleep(3 * time.Second)
+    if _, err := ValidateReport(p, ring, WithLease(l)); err != nil {
+        t.Fatal("renewal", err)
+    }
+    stop()
+    <-done
+    time.Sleep(2100 * time.Millisecond)
+    if _, err := ValidateReport(p, ring, WithLease(l)); !errors.Is(err, ErrLeaseNotHeld) {
+        t.Fatal(err)
+    }
+    if err := l.Renew(ctx); !errors.Is(err, ErrLeaseNotHeld) {
+        t.Fatal(err)
+    }
+    l2, err := Checkout(ctx, srv.URL, "lic-1")
+    if err != nil {
+        t.Fatal("seat not freed", err)
+    }
+    if err := Checkin(ctx, l2); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithLease(l2)); !errors.Is(err, ErrLeaseNotHeld) {
+        t.Fatal(err)
+    }
+}
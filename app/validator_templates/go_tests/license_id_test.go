@@ -0,0 +1,32 @@
+package main
+
+import (
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestLicenseID(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    r, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring)
+    if err != nil || r.LicenseID() != "lic-1" {
+        t.Fatal(err, r.LicenseID())
+    }
+    lic := defaultLicense(time.Now(), 30)
+    delete(lic, "id")
+    p := writeSigned(t, lic)
+    r1, err := ValidateReport(p, ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    r2, _ := ValidateReport(p, ring)
+    ins, _ := Inspect(p, pubB64(t, &testSigner.PublicKey))
+    id := r1.LicenseID()
+    if !strings.HasPrefix(id, "sig-") || len(id) != 36 || r2.LicenseID() != id || ins.LicenseID() != id || r1.AuditRecord().LicenseID != id {
+        t.Fatal(id, r2.LicenseID(), ins.LicenseID())
+    }
+    other, _ := ValidateReport(writeSigned(t, lic), ring)
+    if other.LicenseID() == id {
+        t.Fatal("PSS signatures differ, so IDs should too")
+    }
+}
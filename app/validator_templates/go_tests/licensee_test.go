@@ -0,0 +1,32 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestLicensee(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["licensee"] = map[string]any{"name": "Ada", "company": "Acme", "order_id": "o-9"}
+    r, err := ValidateReport(writeSigned(t, lic), ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    l := r.Licensee()
+    if l.Name != "Ada" || l.Company != "Acme" || l.OrderID != "o-9" || len(l.Fingerprint()) != 64 {
+        t.Fatal(l)
+    }
+    if (Licensee{}).Fingerprint() != "" || (Licensee{Name: "a", Company: "b"}).Fingerprint() == (Licensee{Name: "a\nb"}).Fingerprint() {
+        t.Fatal("fingerprint")
+    }
+    sign := func(b []byte) string {
+        other := defaultLicense(time.Now(), 30)
+        _ = other
+        return pssSign(t, testSigner, b[:len(b)-1])
+    }
+    if _, err := ValidateReport(writeEnvelope(t, lic, sign, nil), ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
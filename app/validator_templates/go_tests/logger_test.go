@@ -0,0 +1,30 @@
+package main
+
+import (
+    "bytes"
+    "crypto/rand"
+    "crypto/rsa"
+    "encoding/json"
+    "log/slog"
+    "os"
+    "testing"
+    "time"
+)
+
+func TestLogger(t *testing.T) {
+    other, _ := rsa.GenerateKey(rand.Reader, 2048)
+    p := writeSigned(t, defaultLicense(time.Now(), 10))
+    var buf bytes.Buffer
+    logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+    if _, err := ValidateReport(p, NewKeyRing(&other.PublicKey), WithLogger(logger)); err == nil {
+        t.Fatal("want error")
+    }
+    var rec map[string]any
+    if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+        t.Fatal(err, buf.String())
+    }
+    if rec["level"] != "DEBUG" || rec["reason"] != "signature" {
+        t.Fatal(rec)
+    }
+    os.Stdout.Sync()
+}
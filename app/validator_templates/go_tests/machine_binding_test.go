@@ -0,0 +1,23 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestMachineBinding(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["machine_id"] = "abc"
+    p := writeSigned(t, lic)
+    if _, err := Validate(p, key, WithMachineID("abc")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(p, key, WithMachineID("abd")); !errors.Is(err, ErrMachineMismatch) {
+        t.Fatal(err)
+    }
+    if id, err := MachineID(); err == nil && id == "" {
+        t.Fatal("empty machine ID")
+    }
+}
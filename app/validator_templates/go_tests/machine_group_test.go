@@ -0,0 +1,30 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestMachineGroup(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["machine_ids"] = []string{"n1", "n2", "n3"}
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithMachineID("n2")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithMachineID("n4")); !errors.Is(err, ErrMachineMismatch) {
+        t.Fatal(err)
+    }
+    lic["machine_id"] = "solo"
+    p = writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithMachineID("solo")); err != nil {
+        t.Fatal(err)
+    }
+    delete(lic, "machine_ids")
+    p = writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithMachineID("n1")); !errors.Is(err, ErrMachineMismatch) {
+        t.Fatal(err)
+    }
+}
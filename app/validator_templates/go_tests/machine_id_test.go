@@ -0,0 +1,49 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "runtime"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMachineFingerprint(t *testing.T) {
+    id, err := MachineID()
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(id) != 64 {
+        t.Fatal(id)
+    }
+    if raw, err := osMachineID(); err == nil {
+        if strings.Contains(id, raw) {
+            t.Fatal("raw leaked")
+        }
+        // A license bound to the raw OS ID does not match the hashed one.
+        lic := defaultLicense(time.Now(), 30)
+        lic["machine_id"] = raw
+        if _, err := Validate(writeSigned(t, lic), pubB64(t, &testSigner.PublicKey)); !errors.Is(err, ErrMachineMismatch) {
+            t.Fatal(err)
+        }
+    }
+    again, _ := MachineID()
+    if again != id {
+        t.Fatal("unstable")
+    }
+    h, err := Fingerprint(FactorHostname, "bogus")
+    if err != nil || h == id {
+        t.Fatal(h, err)
+    }
+    if _, err := Fingerprint("bogus"); err == nil {
+        t.Fatal()
+    }
+    if runtime.GOOS == "linux" {
+        if _, err := os.Stat("/etc/machine-id"); err == nil {
+            if _, err := Fingerprint(FactorOSID); err != nil {
+                t.Fatal(err)
+            }
+        }
+    }
+}
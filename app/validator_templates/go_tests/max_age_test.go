@@ -0,0 +1,31 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestMaxAge(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 365))
+    clk := &fixedClock{issued.Add(time.Hour)}
+    if _, err := ValidateReport(p, ring, WithClock(clk), WithMaxAge(24*time.Hour)); err != nil {
+        t.Fatal(err)
+    }
+    clk.t = issued.Add(48 * time.Hour)
+    if _, err := ValidateReport(p, ring, WithClock(clk), WithMaxAge(24*time.Hour)); !errors.Is(err, ErrLicenseStale) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithClock(clk)); err != nil {
+        t.Fatal(err)
+    }
+    lic := defaultLicense(issued, 365)
+    delete(lic, "issued_at")
+    delete(lic, "validity_days")
+    lic["expires_at"] = issued.AddDate(1, 0, 0).Format(time.RFC3339)
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithClock(clk), WithMaxAge(24*time.Hour)); !errors.Is(err, ErrLicenseStale) {
+        t.Fatal(err)
+    }
+}
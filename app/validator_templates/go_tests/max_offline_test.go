@@ -0,0 +1,57 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestMaxOffline(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 365))
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, `{"revoked":false}`) }))
+    dir := t.TempDir()
+    store, _ := NewFileStateStore(dir)
+    clk := &movingClock{t: issued.AddDate(0, 0, 1)}
+    opts := func(url string) []Option {
+        return []Option{WithClock(clk), WithRevocationURL(url), WithOfflinePolicy(FailOpen), WithMaxOfflineDuration(7*24*time.Hour, store)}
+    }
+    dead := httptest.NewServer(http.NotFoundHandler())
+    dead.Close()
+    if _, err := ValidateReport(p, ring, opts(dead.URL)...); !errors.Is(err, ErrOfflineTooLong) {
+        t.Fatal("never online", err)
+    }
+    if _, err := ValidateReport(p, ring, opts(srv.URL)...); err != nil {
+        t.Fatal(err)
+    }
+    clk.set(issued.AddDate(0, 0, 7))
+    if _, err := ValidateReport(p, ring, opts(dead.URL)...); err != nil {
+        t.Fatal("within window", err)
+    }
+    clk.set(issued.AddDate(0, 0, 9))
+    if _, err := ValidateReport(p, ring, opts(dead.URL)...); !errors.Is(err, ErrOfflineTooLong) {
+        t.Fatal("past window", err)
+    }
+    if _, err := ValidateReport(p, ring, opts(srv.URL)...); err != nil {
+        t.Fatal(err)
+    }
+    clk.set(issued.AddDate(0, 0, 2))
+    if _, err := ValidateReport(p, ring, opts(dead.URL)...); !errors.Is(err, ErrClockRollback) {
+        t.Fatal("rollback", err)
+    }
+    f := filepath.Join(dir, fmt.Sprintf("%x", "last_online"))
+    data, _ := os.ReadFile(f)
+    data[3] ^= 1
+    os.WriteFile(f, data, 0o600)
+    clk.set(issued.AddDate(0, 0, 10))
+    if _, err := ValidateReport(p, ring, opts(dead.URL)...); !errors.Is(err, ErrOfflineTooLong) {
+        t.Fatal("tampered", err)
+    }
+    srv.Close()
+}
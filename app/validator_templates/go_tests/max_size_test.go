@@ -0,0 +1,30 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMaxSize(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    info, _ := os.Stat(p)
+    if _, err := ValidateReport(p, ring, WithMaxLicenseSize(info.Size())); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithMaxLicenseSize(info.Size()-1)); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+    big := filepath.Join(t.TempDir(), "big")
+    os.WriteFile(big, make([]byte, MaxLicenseSize+1), 0o600)
+    if _, err := ValidateReport(big, ring); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReader(strings.NewReader(strings.Repeat("a", 11)), ring, WithMaxLicenseSize(10)); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestMaxValidity(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    opt := WithMaxValidityDuration(5 * 365 * 24 * time.Hour)
+    now := time.Now()
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(now, 365)), ring, opt); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(now, 200*365)), ring, opt); !errors.Is(err, ErrValidityTooLong) {
+        t.Fatal(err)
+    }
+    lic := defaultLicense(now, 0)
+    delete(lic, "validity_days")
+    lic["expires_at"] = now.AddDate(200, 0, 0).Format(time.RFC3339)
+    if _, err := ValidateReport(writeSigned(t, lic), ring, opt); !errors.Is(err, ErrValidityTooLong) {
+        t.Fatal(err)
+    }
+    delete(lic, "issued_at")
+    lic["expires_at"] = now.AddDate(1, 0, 0).Format(time.RFC3339)
+    if _, err := ValidateReport(writeSigned(t, lic), ring, opt); !errors.Is(err, ErrValidityTooLong) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, lic), ring); err != nil {
+        t.Fatal(err)
+    }
+}
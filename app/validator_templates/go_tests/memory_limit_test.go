@@ -0,0 +1,115 @@
+package main
+
+import (
+    "bytes"
+    "compress/gzip"
+    "crypto/rand"
+    "encoding/base64"
+    "encoding/binary"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestMemoryLimit(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), Features: map[string]Feature{}}
+    for i := 0; i < 200; i++ {
+        payload.Features[string(rune('a'+i%26))+string(rune('a'+i/26))] = Feature{Enabled: true, Value: "some value to make it compress"}
+    }
+    d, _ := Sign(payload, testSigner, WithCompression())
+    b, _ := json.Marshal(d)
+    b = []byte(serverEncrypt(t, b))
+    if _, err := ValidateBytes(b, ring, WithMaxMemory(1<<20)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(b, ring, WithMaxMemory(int64(len(b)))); !errors.Is(err, ErrResourceLimit) {
+        t.Fatal(err)
+    }
+    path := filepath.Join(t.TempDir(), "l.lic")
+    os.WriteFile(path, b, 0o600)
+    est, err := EstimateResourceUsage(path)
+    if err != nil || est.FileSize != int64(len(b)) || est.DecryptedSize == 0 {
+        t.Fatal(est, err)
+    }
+
+    // Declare a huge inflated size in the gzip trailer.
+    var encoded string
+    json.Unmarshal(d.License, &encoded)
+    gz, _ := base64.StdEncoding.DecodeString(encoded)
+    crafted := func(size uint32) []byte {
+        g := append([]byte(nil), gz...)
+        binary.LittleEndian.PutUint32(g[len(g)-4:], size)
+        c := d
+        c.License, _ = json.Marshal(base64.StdEncoding.EncodeToString(g))
+        out, _ := json.Marshal(c)
+        return []byte(serverEncrypt(t, out))
+    }
+    if _, err := ValidateBytes(crafted(64<<20), ring, WithMaxMemory(1<<20)); !errors.Is(err, ErrResourceLimit) {
+        t.Fatal(err)
+    }
+    // Without a memory limit the trailer is only checked by gzip itself.
+    if _, err := ValidateBytes(crafted(64<<20), ring); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    // A bomb whose trailer understates its size is caught by the hard limits.
+    bomb := func(size int) []byte {
+        var zb bytes.Buffer
+        zw := gzip.NewWriter(&zb)
+        zw.Write(make([]byte, size))
+        zw.Close()
+        g := zb.Bytes()
+        binary.LittleEndian.PutUint32(g[len(g)-4:], 100)
+        enc := base64.StdEncoding.EncodeToString(g)
+        lb, _ := json.Marshal(enc)
+        out, _ := json.Marshal(LicenseData{License: lb, Compressed: true, Signature: pssSign(t, testSigner, []byte(enc))})
+        return []byte(serverEncrypt(t, out))
+    }
+    if _, err := ValidateBytes(bomb(MaxDecompressedSize+1), ring); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(bomb(1<<20), ring, WithMaxMemory(1<<19)); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+    // A multi-member gzip records only its last member in the trailer.
+    var buf bytes.Buffer
+    raw, _ := json.Marshal(payload)
+    for _, part := range [][]byte{raw[:len(raw)/2], raw[len(raw)/2:]} {
+        zw := gzip.NewWriter(&buf)
+        zw.Write(part)
+        zw.Close()
+    }
+    encodedMulti := base64.StdEncoding.EncodeToString(buf.Bytes())
+    lm, _ := json.Marshal(encodedMulti)
+    multi, _ := json.Marshal(LicenseData{License: lm, Compressed: true, Signature: pssSign(t, testSigner, []byte(encodedMulti))})
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, multi)), ring); err != nil {
+        t.Fatal(err)
+    }
+
+    // Compressed inside the encryption: checked once decrypted.
+    key := make([]byte, 32)
+    rand.Read(key)
+    d, _ = Sign(payload, testSigner, WithCompression(), WithPayloadEncryption(key, "base64"))
+    b, _ = json.Marshal(d)
+    est = estimateResources(b)
+    if est.DecompressedSize != -1 || est.DecryptedSize == 0 {
+        t.Fatal(est)
+    }
+    if _, err := ValidateBytes(b, ring, WithDecryptionKey(key), WithMaxMemory(1<<20)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(b, ring, WithDecryptionKey(key), WithMaxMemory(est.Total()+100)); !errors.Is(err, ErrResourceLimit) {
+        t.Fatal(err)
+    }
+    plain, _ := Sign(payload, testSigner, WithCompression())
+    pb, _ := json.Marshal(plain)
+    if est := estimateResources(pb); est.DecompressedSize < 5000 || est.Total() < est.DecompressedSize*3 {
+        t.Fatal(est)
+    }
+    if _, err := NewValidator(WithKeyRing(ring), WithMaxMemory(0)); err == nil {
+        t.Fatal("zero limit accepted")
+    }
+}
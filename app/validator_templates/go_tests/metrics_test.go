@@ -0,0 +1,34 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMetrics(t *testing.T) {
+    m := NewMetrics()
+    other, _ := rsa.GenerateKey(rand.Reader, 2048)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    good := writeSigned(t, defaultLicense(time.Now(), 30))
+    old := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -60), 30))
+    crl, _ := ParseRevocationList(signedCRL(t, "lic-1"), ring)
+    ValidateReport(good, ring, WithMetrics(m))
+    ValidateReport(good, ring, WithMetrics(m))
+    ValidateReport(old, ring, WithMetrics(m))
+    ValidateReport(good, ring, WithMetrics(m), WithRevocationList(crl))
+    ValidateReport(good, NewKeyRing(&other.PublicKey), WithMetrics(m))
+    ValidateReport("/nope", ring, WithMetrics(m))
+    for o, n := range map[string]uint64{OutcomeValid: 2, OutcomeExpired: 1, OutcomeRevoked: 1, OutcomeBadSignature: 1, OutcomeInvalid: 1} {
+        if m.Count(o) != n {
+            t.Fatal(o, m.Count(o))
+        }
+    }
+    var b strings.Builder
+    m.WritePrometheus(&b)
+    if !strings.Contains(b.String(), `sigma_permit_validations_total{outcome="valid"} 2`) || !strings.Contains(b.String(), "sigma_permit_license_expiry_seconds 25") {
+        t.Fatal(b.String())
+    }
+}
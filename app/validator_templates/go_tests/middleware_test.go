@@ -0,0 +1,37 @@
+package main
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestMiddleware(t *testing.T) {
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)))
+    ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(204) })
+    lic := defaultLicense(time.Now(), 30)
+    lic["features"] = map[string]any{"pro": true}
+    good := writeSigned(t, lic)
+    expired := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30))
+    for _, c := range []struct {
+        path     string
+        features []string
+        code     int
+        reason   string
+    }{{good, nil, 204, ""}, {good, []string{"pro"}, 204, ""}, {good, []string{"enterprise"}, 402, "feature"}, {expired, nil, 402, "expired"}} {
+        rec := httptest.NewRecorder()
+        LicenseMiddleware(v, c.path, c.features...)(ok).ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+        if rec.Code != c.code {
+            t.Fatal(c, rec.Code, rec.Body.String())
+        }
+        if c.code == 402 {
+            var body map[string]string
+            json.Unmarshal(rec.Body.Bytes(), &body)
+            if body["reason"] != c.reason || rec.Header().Get("Content-Type") != "application/json" {
+                t.Fatal(body)
+            }
+        }
+    }
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+    "bytes"
+    "os"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestMustValidate(t *testing.T) {
+    var out bytes.Buffer
+    code := -1
+    exit, exitOutput = func(c int) { code = c }, &out
+    defer func() { exit, exitOutput = os.Exit, os.Stderr }()
+    key := pubB64(t, &testSigner.PublicKey)
+    if r := MustValidate(writeSigned(t, defaultLicense(time.Now(), 30)), key); r == nil || code != -1 || out.Len() != 0 {
+        t.Fatal(code, out.String())
+    }
+    if r := MustValidate(writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)), key); r != nil || code != ExitLicenseExpired || out.String() != "Your license has expired; please renew.\n" {
+        t.Fatal(code, out.String())
+    }
+    out.Reset()
+    MustValidate(t.TempDir()+"/none.lic", key)
+    if code != ExitLicenseMissing || !strings.Contains(out.String(), "install") {
+        t.Fatal(code, out.String())
+    }
+    out.Reset()
+    lic := defaultLicense(time.Now(), 30)
+    lic["machine_id"] = "other"
+    MustValidate(writeSigned(t, lic), key, WithMachineID("me"))
+    if code != ExitLicenseBinding || out.String() != "Your license is for a different machine.\n" {
+        t.Fatal(code, out.String())
+    }
+    out.Reset()
+    code = -1
+    if r := MustValidate(writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -31), 30)), key, WithGracePeriod(72*time.Hour)); r == nil || code != -1 || !strings.Contains(out.String(), "grace") {
+        t.Fatal(code, out.String())
+    }
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+    "errors"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestNonce(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["nonce"] = "n-1"
+    p := writeSigned(t, lic)
+    store := NewMemoryNonceStore()
+    cache := NewResultCache(time.Hour)
+    if _, err := ValidateReport(p, ring, WithNonceStore(store), WithResultCache(cache)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithNonceStore(store), WithResultCache(cache)); !errors.Is(err, ErrReplay) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    store.Record("old", time.Now().Add(-time.Second))
+    if store.Seen("old") {
+        t.Fatal("expired nonce seen")
+    }
+}
+
+func TestNonceConcurrentReplay(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["nonce"] = "n-race"
+    p := writeSigned(t, lic)
+    state, _ := NewFileStateStore(t.TempDir())
+    for name, store := range map[string]NonceStore{"memory": NewMemoryNonceStore(), "state": StateNonceStore(state)} {
+        var wg sync.WaitGroup
+        var accepted atomic.Int32
+        for i := 0; i < 16; i++ {
+            wg.Add(1)
+            go func() {
+                defer wg.Done()
+                if _, err := ValidateReport(p, ring, WithNonceStore(store)); err == nil {
+                    accepted.Add(1)
+                } else if !errors.Is(err, ErrReplay) {
+                    t.Error(err)
+                }
+            }()
+        }
+        wg.Wait()
+        if accepted.Load() != 1 {
+            t.Fatal(name, accepted.Load())
+        }
+    }
+    m := NewMemoryNonceStore()
+    if !m.Consume("x", time.Time{}) || m.Consume("x", time.Time{}) || !m.Seen("x") {
+        t.Fatal("consume")
+    }
+}
@@ -0,0 +1,23 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestNotBefore(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["not_before"] = time.Now().Add(time.Hour).Format(time.RFC3339)
+    if _, err := Validate(writeSigned(t, lic), key); !errors.Is(err, ErrNotYetValid) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeSigned(t, lic), key, WithClockSkew(2*time.Hour)); err != nil {
+        t.Fatal(err)
+    }
+    lic["not_before"] = time.Now().Add(-time.Hour).Format(time.RFC3339)
+    if _, err := Validate(writeSigned(t, lic), key); err != nil {
+        t.Fatal(err)
+    }
+}
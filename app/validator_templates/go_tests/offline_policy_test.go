@@ -0,0 +1,54 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "math/big"
+    "encoding/base64"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestOfflinePolicy(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        time.Sleep(300 * time.Millisecond)
+    }))
+    defer slow.Close()
+    client := WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond})
+    if _, err := ValidateReport(p, ring, client, WithRevocationURL(slow.URL)); !errors.Is(err, ErrRevocationCheck) {
+        t.Fatal("default", err)
+    }
+    if _, err := ValidateReport(p, ring, client, WithRevocationURL(slow.URL), WithOfflinePolicy(FailOpen)); err != nil {
+        t.Fatal(err)
+    }
+
+    var down atomic.Bool
+    b := base64.RawURLEncoding.EncodeToString
+    jw := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if down.Load() {
+            time.Sleep(300 * time.Millisecond)
+            return
+        }
+        fmt.Fprintf(w, `{"keys":[{"kty":"RSA","kid":"r1","n":%q,"e":%q}]}`, b(testSigner.N.Bytes()), b(big.NewInt(int64(testSigner.E)).Bytes()))
+    }))
+    defer jw.Close()
+    src := NewJWKS(jw.URL, time.Millisecond)
+    client = WithHTTPClient(&http.Client{Timeout: 50 * time.Millisecond, Transport: jw.Client().Transport})
+    kp := writeEnvelope(t, defaultLicense(time.Now(), 30), func(bb []byte) string { return pssSign(t, testSigner, bb) }, map[string]any{"kid": "r1"})
+    if _, err := ValidateKeyRing(kp, nil, WithKeySource(src), client); err != nil {
+        t.Fatal(err)
+    }
+    down.Store(true)
+    time.Sleep(5 * time.Millisecond)
+    if _, err := ValidateKeyRing(kp, nil, WithKeySource(src), client); !errors.Is(err, ErrKeyFetchFailed) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateKeyRing(kp, nil, WithKeySource(src), client, WithOfflinePolicy(FailOpen)); err != nil {
+        t.Fatal(err)
+    }
+}
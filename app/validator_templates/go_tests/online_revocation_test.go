@@ -0,0 +1,38 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestOnlineRevocation(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var b map[string]string
+        json.NewDecoder(r.Body).Decode(&b)
+        fmt.Fprintf(w, `{"revoked":%v}`, b["license_id"] == "lic-1")
+    }))
+    defer srv.Close()
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateReport(p, ring, WithRevocationURL(srv.URL)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    lic := defaultLicense(time.Now(), 30)
+    lic["id"] = "ok"
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithRevocationURL(srv.URL)); err != nil {
+        t.Fatal(err)
+    }
+    dead := httptest.NewServer(http.NotFoundHandler())
+    dead.Close()
+    if _, err := ValidateReport(p, ring, WithRevocationURL(dead.URL), WithOfflinePolicy(FailOpen)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithRevocationURL(dead.URL), WithRevocationFailClosed()); !errors.Is(err, ErrRevocationCheck) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestPassphrase(t *testing.T) {
+    d, _ := Sign(LicensePayload{ID: "pw", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}, testSigner)
+    b, _ := json.Marshal(d)
+    sealed, err := SealLicenseWithPassphrase(b, "hunter2", MinPBKDF2Iterations)
+    if err != nil {
+        t.Fatal(err)
+    }
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if r, err := ValidateBytes([]byte(sealed), ring, WithPassphrase("hunter2")); err != nil || r.Payload.ID != "pw" {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes([]byte(sealed), ring, WithPassphrase("wrong")); !errors.Is(err, ErrWrongPassphrase) || !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    if _, err := SealLicenseWithPassphrase(b, "x", 1000); err == nil {
+        t.Fatal("weak accepted")
+    }
+    weak := strings.Replace(sealed, "pbkdf2$600000$", "pbkdf2$1000$", 1)
+    if _, err := ValidateBytes([]byte(weak), ring, WithPassphrase("hunter2")); !errors.Is(err, ErrDecryptionFailed) || errors.Is(err, ErrWrongPassphrase) {
+        t.Fatal(err)
+    }
+}
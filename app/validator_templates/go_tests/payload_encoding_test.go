@@ -0,0 +1,54 @@
+package main
+
+import (
+    "crypto/rand"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestPayloadEncoding(t *testing.T) {
+    key := make([]byte, 32)
+    rand.Read(key)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "enc", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}
+    var claims []string
+    for _, enc := range []string{"", "base64", "hex"} {
+        d, err := Sign(payload, testSigner, WithPayloadEncryption(key, enc))
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(d)
+        p := filepath.Join(t.TempDir(), "l.lic")
+        os.WriteFile(p, b, 0o600)
+        r, err := ValidateReport(p, ring, WithDecryptionKey(key))
+        if err != nil || r.Payload.ID != "enc" {
+            t.Fatal(enc, err)
+        }
+        claims = append(claims, string(r.Claims))
+        if _, err := ValidateReport(p, ring); !errors.Is(err, ErrDecryptionFailed) {
+            t.Fatal(err)
+        }
+        d.EncryptedPayload = strings.ToUpper(d.EncryptedPayload)
+        b, _ = json.Marshal(d)
+        if _, err := ValidateBytes(b, ring, WithDecryptionKey(key)); err == nil {
+            t.Fatal("tampered accepted", enc)
+        }
+    }
+    if claims[0] != claims[1] || claims[1] != claims[2] {
+        t.Fatal(claims)
+    }
+    d, _ := Sign(payload, testSigner, WithPayloadEncryption(key, "hex"))
+    d.PayloadEncoding = "base32"
+    b, _ := json.Marshal(d)
+    if _, err := ValidateBytes(b, ring, WithDecryptionKey(key)); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    if _, err := Sign(payload, testSigner, WithPayloadEncryption(key, "base32")); err == nil {
+        t.Fatal("bad encoding")
+    }
+}
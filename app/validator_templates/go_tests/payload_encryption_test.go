@@ -0,0 +1,35 @@
+package main
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestPayloadDecryption(t *testing.T) {
+    k := make([]byte, 32)
+    k[0] = 7
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": pssSign(t, testSigner, lb)})
+    sealed, err := SealLicense(data, k)
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := filepath.Join(t.TempDir(), "l")
+    os.WriteFile(p, []byte(sealed), 0o600)
+    key := pubB64(t, &testSigner.PublicKey)
+    if _, err := Validate(p, key, WithDecryptionKey(k)); err != nil {
+        t.Fatal(err)
+    }
+    raw, _ := base64.StdEncoding.DecodeString(sealed)
+    raw[20] ^= 1
+    os.WriteFile(p, []byte(base64.StdEncoding.EncodeToString(raw)), 0o600)
+    _, err = Validate(p, key, WithDecryptionKey(k))
+    if !errors.Is(err, ErrAuthTagMismatch) || !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+}
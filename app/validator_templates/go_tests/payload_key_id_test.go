@@ -0,0 +1,42 @@
+package main
+
+import (
+    "crypto/rand"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestPayloadKeyID(t *testing.T) {
+    keys := map[string][]byte{}
+    for _, id := range []string{"k1", "k2", "k3"} {
+        k := make([]byte, 32)
+        rand.Read(k)
+        keys[id] = k
+    }
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    d, err := Sign(payload, testSigner, WithPayloadEncryption(keys["k2"], "base64"), WithPayloadKeyID("k2"))
+    if err != nil || d.EncKid != "k2" {
+        t.Fatal(err, d.EncKid)
+    }
+    b, _ := json.Marshal(d)
+    if _, err := ValidateBytes(b, ring, WithDecryptionKeys(keys)); err != nil {
+        t.Fatal(err)
+    }
+    swapped := map[string][]byte{"k1": keys["k1"], "k2": keys["k3"]}
+    if _, err := ValidateBytes(b, ring, WithDecryptionKeys(swapped)); !errors.Is(err, ErrDecryptionFailed) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(b, ring, WithDecryptionKeys(map[string][]byte{"k1": keys["k1"]})); !errors.Is(err, ErrUnknownEncKeyID) {
+        t.Fatal(err)
+    }
+    // Single-key mode ignores the enc_kid.
+    if _, err := ValidateBytes(b, ring, WithDecryptionKey(keys["k2"])); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithDecryptionKeys(map[string][]byte{"x": {1}})); err == nil {
+        t.Fatal("short key accepted")
+    }
+}
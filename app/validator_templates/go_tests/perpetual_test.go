@@ -0,0 +1,23 @@
+package main
+
+import (
+    "errors"
+    "testing"
+)
+
+func TestPerpetual(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := map[string]any{"id": "p", "perpetual": true, "payload": nil}
+    p := writeSigned(t, lic)
+    r, err := ValidateReport(p, ring, WithAllowPerpetual(), WithResultCache(NewResultCache(1e12)))
+    if err != nil || !r.ExpiresAt.IsZero() {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring); !errors.Is(err, ErrMissingExpiration) {
+        t.Fatal(err)
+    }
+    delete(lic, "perpetual")
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithAllowPerpetual()); !errors.Is(err, ErrMissingExpiration) {
+        t.Fatal(err)
+    }
+}
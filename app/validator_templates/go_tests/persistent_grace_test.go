@@ -0,0 +1,58 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestPersistentGrace(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    exp := issued.AddDate(0, 0, 30)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    dir := t.TempDir()
+    run := func(at time.Time) (*Report, error) {
+        store, err := NewFileStateStore(dir)
+        if err != nil {
+            t.Fatal(err)
+        }
+        v, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(&fixedClock{at}), WithGracePeriod(7*24*time.Hour), WithPersistentGrace(store))
+        if err != nil {
+            t.Fatal(err)
+        }
+        return v.Validate(p)
+    }
+    if _, err := run(exp.AddDate(0, 0, -1)); err != nil {
+        t.Fatal(err)
+    }
+    r, err := run(exp.AddDate(0, 0, 1))
+    if !errors.Is(err, ErrInGracePeriod) || r.GraceRemaining != 6*24*time.Hour {
+        t.Fatal(err, r)
+    }
+    // A restart later in grace continues the countdown.
+    r, err = run(exp.AddDate(0, 0, 3))
+    if !errors.Is(err, ErrInGracePeriod) || r.GraceRemaining != 4*24*time.Hour {
+        t.Fatal(err, r)
+    }
+    for _, back := range []time.Time{exp.AddDate(0, 0, -2), exp.Add(12 * time.Hour)} {
+        if _, err := run(back); !errors.Is(err, ErrClockRollback) {
+            t.Fatal(back, err)
+        }
+    }
+    if _, err := run(exp.AddDate(0, 0, 8)); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    files, _ := filepath.Glob(filepath.Join(dir, "*"))
+    if len(files) != 1 {
+        t.Fatal(files)
+    }
+    os.WriteFile(files[0], []byte("2026-02-01T00:00:00Z\n00"), 0o600)
+    if _, err := run(exp.AddDate(0, 0, 2)); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    if _, err := run(exp.AddDate(0, 0, -1)); err != nil {
+        t.Fatal(err)
+    }
+}
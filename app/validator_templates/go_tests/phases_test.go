@@ -0,0 +1,55 @@
+package main
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "reflect"
+    "sync"
+    "testing"
+    "time"
+)
+
+type tickClock struct {
+    mu sync.Mutex
+    t  time.Time
+}
+
+func (c *tickClock) Now() time.Time {
+    c.mu.Lock()
+    defer c.mu.Unlock()
+    c.t = c.t.Add(time.Millisecond)
+    return c.t
+}
+
+func TestPhaseTimings(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"revoked":false}`)) }))
+    defer srv.Close()
+    var phases []string
+    var durs []time.Duration
+    clk := &tickClock{t: time.Now()}
+    v, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(clk), WithRevocationURL(srv.URL),
+        WithHooks(Hooks{OnPhase: func(p string, d time.Duration) { phases = append(phases, p); durs = append(durs, d) }}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, err := v.Validate(writeSigned(t, defaultLicense(time.Now(), 30))); err != nil {
+        t.Fatal(err)
+    }
+    want := []string{PhaseRead, PhaseDecrypt, PhaseParse, PhaseVerify, PhaseRevocation}
+    if !reflect.DeepEqual(phases, want) {
+        t.Fatal(phases)
+    }
+    for i, d := range durs {
+        if d < time.Millisecond || d > 10*time.Millisecond {
+            t.Fatal(phases[i], d)
+        }
+    }
+    // No hook, no clock reads.
+    clk2 := &tickClock{t: time.Now()}
+    v2, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(clk2))
+    before := clk2.t
+    v2.Validate(writeSigned(t, defaultLicense(time.Now(), 30)))
+    if n := clk2.t.Sub(before) / time.Millisecond; n > 2 {
+        t.Fatal("clock read", n)
+    }
+}
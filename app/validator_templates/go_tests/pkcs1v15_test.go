@@ -0,0 +1,32 @@
+package main
+
+import (
+    "crypto"
+    "crypto/rand"
+    "crypto/rsa"
+    "crypto/sha256"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestPKCS1v15(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    v15 := func(b []byte) string {
+        h := sha256.Sum256(b)
+        s, _ := rsa.SignPKCS1v15(rand.Reader, testSigner, crypto.SHA256, h[:])
+        return base64.StdEncoding.EncodeToString(s)
+    }
+    pss := func(b []byte) string { return pssSign(t, testSigner, b) }
+    if _, err := Validate(writeEnvelope(t, lic, v15, map[string]any{"alg": "RS256"}), key, allowRS); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, v15, nil), key, allowRS); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := Validate(writeEnvelope(t, lic, pss, map[string]any{"alg": "RS256"}), key, allowRS); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
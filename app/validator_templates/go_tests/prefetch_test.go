@@ -0,0 +1,63 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestPrefetchRevocations(t *testing.T) {
+    var calls atomic.Int32
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        calls.Add(1)
+        var b map[string]any
+        json.NewDecoder(r.Body).Decode(&b)
+        if _, ok := b["license_ids"]; ok {
+            // Partial: "c" missing, "d" garbage.
+            w.Write([]byte(`{"statuses":{"a":{"status":"active"},"b":{"revoked":true},"d":{"status":"weird"}}}`))
+            return
+        }
+        w.Write([]byte(`{"revoked":false}`))
+    }))
+    defer srv.Close()
+    ring := NewKeyRing(&testSigner.PublicKey)
+    v, _ := NewValidator(WithKeyRing(ring), WithRevocationURL(srv.URL))
+    if err := v.PrefetchRevocations(t.Context(), []string{"a", "b", "c", "d"}); err != nil {
+        t.Fatal(err)
+    }
+    if calls.Load() != 1 {
+        t.Fatal(calls.Load())
+    }
+    lic := func(id string) string {
+        l := defaultLicense(time.Now(), 30)
+        l["id"] = id
+        return writeSigned(t, l)
+    }
+    if _, err := v.Validate(lic("a")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := v.Validate(lic("b")); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    if calls.Load() != 1 {
+        t.Fatal("network used", calls.Load())
+    }
+    if _, err := v.Validate(lic("c")); err != nil || calls.Load() != 2 {
+        t.Fatal(err, calls.Load())
+    }
+    if _, err := v.Validate(lic("d")); err != nil || calls.Load() != 3 {
+        t.Fatal(err, calls.Load())
+    }
+    v2, _ := NewValidator(WithKeyRing(ring), WithRevocationURL(srv.URL), WithPrefetchTTL(time.Nanosecond))
+    v2.PrefetchRevocations(t.Context(), []string{"a"})
+    time.Sleep(time.Millisecond)
+    before := calls.Load()
+    v2.Validate(lic("a"))
+    if calls.Load() != before+1 {
+        t.Fatal("stale prefetch used")
+    }
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestPreview(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["machine_id"] = "other"
+    lic["features"] = map[string]any{"pro": map[string]any{"enabled": true}}
+    p := writeSigned(t, lic)
+    key := pubB64(t, &testSigner.PublicKey)
+    if _, err := Validate(p, key, WithMachineID("me")); !errors.Is(err, ErrMachineMismatch) {
+        t.Fatal(err)
+    }
+    r, err := Preview(p, key, WithMachineID("me"))
+    if err != nil {
+        t.Fatal(err, r.Checks)
+    }
+    if !r.Preview || r.HasFeature("pro") || r.Payload.ID != "lic-1" {
+        t.Fatal(r)
+    }
+    found := false
+    for _, c := range r.Checks {
+        if c.Name == "machine" {
+            found = c.Status == CheckSkip && c.Detail == "skipped by Preview"
+        }
+    }
+    if !found {
+        t.Fatal(r.Checks)
+    }
+    old := defaultLicense(time.Now().AddDate(0, 0, -40), 30)
+    if r, err := Preview(writeSigned(t, old), key); !errors.Is(err, ErrLicenseExpired) || r == nil {
+        t.Fatal(err)
+    }
+}
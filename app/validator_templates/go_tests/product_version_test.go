@@ -0,0 +1,40 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestProductVersion(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["max_product_version"] = "2.4.0"
+    p := writeSigned(t, lic)
+    for v, ok := range map[string]bool{"2.4.0": true, "v2.3.99": true, "2.4.0-rc.1": true, "2.4.0+build5": true, "2.4.1": false, "3.0.0-alpha": false, "10.0.0": false} {
+        _, err := ValidateReport(p, ring, WithProductVersion(v))
+        if ok && err != nil || !ok && !errors.Is(err, ErrVersionNotCovered) {
+            t.Fatal(v, err)
+        }
+    }
+    if _, err := ValidateReport(p, ring, WithProductVersion("2.4")); err == nil {
+        t.Fatal("bad validator version")
+    }
+    lic["max_product_version"] = "latest"
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithProductVersion("1.0.0")); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    a, _ := parseSemver("1.0.0-alpha")
+    for _, s := range []string{"1.0.0-alpha.1", "1.0.0-alpha.beta", "1.0.0-beta", "1.0.0-beta.2", "1.0.0-beta.11", "1.0.0-rc.1", "1.0.0"} {
+        b, err := parseSemver(s)
+        if err != nil || a.compare(b) >= 0 {
+            t.Fatal(a.text, s, err)
+        }
+        a = b
+    }
+    for _, s := range []string{"01.0.0", "1.0.0-", "1.0.0-01", "1..0", "1.0.x"} {
+        if _, err := parseSemver(s); err == nil {
+            t.Fatal(s)
+        }
+    }
+}
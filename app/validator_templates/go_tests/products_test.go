@@ -0,0 +1,31 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestProducts(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    now := time.Now()
+    lic := defaultLicense(now, 365)
+    lic["products"] = []map[string]any{
+        {"id": "editor", "expires_at": now.AddDate(0, 0, -1).Format(time.RFC3339), "features": map[string]any{"export": true}},
+        {"id": "viewer", "features": map[string]any{"print": true}},
+    }
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithProduct("editor")); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    r, err := ValidateReport(p, ring, WithProduct("viewer"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !r.HasFeature("print") || r.HasFeature("export") || r.Product.ID != "viewer" {
+        t.Fatal(r.Product)
+    }
+    if _, err := ValidateReport(p, ring, WithProduct("crm")); !errors.Is(err, ErrProductNotLicensed) {
+        t.Fatal(err)
+    }
+}
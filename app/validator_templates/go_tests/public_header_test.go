@@ -0,0 +1,77 @@
+package main
+
+import (
+    "crypto/rand"
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestPublicHeader(t *testing.T) {
+    key := make([]byte, 32)
+    rand.Read(key)
+    pub := pubB64(t, &testSigner.PublicKey)
+    write := func(d LicenseData) string {
+        b, _ := json.Marshal(d)
+        p := filepath.Join(t.TempDir(), "l.lic")
+        os.WriteFile(p, b, 0o600)
+        return p
+    }
+    exp := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+    payload := LicensePayload{ID: "hdr", IssuedAt: time.Now().UTC().Format(time.RFC3339), ExpiresAt: exp.Format(time.RFC3339)}
+    d, err := Sign(payload, testSigner, WithPayloadEncryption(key, ""), WithCompression(), WithPublicHeader())
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := write(d)
+    h, err := VerifyHeader(p, pub)
+    if err != nil || h.ID != "hdr" || h.ExpiresAt != exp.Format(time.RFC3339) {
+        t.Fatal(h, err)
+    }
+    if _, err := Validate(p, pub, WithDecryptionKey(key)); err != nil {
+        t.Fatal(err)
+    }
+    // Expired header.
+    old := payload
+    old.ExpiresAt = time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+    d2, _ := Sign(old, testSigner, WithPayloadEncryption(key, ""), WithPublicHeader())
+    if h, err := VerifyHeader(write(d2), pub); !errors.Is(err, ErrLicenseExpired) || h == nil {
+        t.Fatal(err)
+    }
+    // Swapping in another header breaks the signature.
+    d3 := d
+    d3.Header = d2.Header
+    if _, err := VerifyHeader(write(d3), pub); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    // A header that disagrees with the payload fails full validation.
+    forged := d2
+    forgedPayload := payload
+    forgedPayload.ExpiresAt = time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+    d4, _ := Sign(forgedPayload, testSigner, WithPayloadEncryption(key, ""))
+    forged.EncryptedPayload = d4.EncryptedPayload
+    forged.Signature = d4.Signature
+    if _, err := Validate(write(forged), pub, WithDecryptionKey(key)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    raw, _ := json.Marshal(payload)
+    sealed, _ := SealLicense(raw, key)
+    hdr, _ := json.Marshal(LicenseHeader{ID: "hdr", ExpiresAt: time.Now().Add(999 * time.Hour).UTC().Format(time.RFC3339)})
+    lie := LicenseData{EncryptedPayload: sealed, Header: hdr, Signature: pssSign(t, testSigner, sealedMessage(hdr, sealed))}
+    if _, err := VerifyHeader(write(lie), pub); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := Validate(write(lie), pub, WithDecryptionKey(key)); !errors.Is(err, ErrInconsistentClaims) {
+        t.Fatal(err)
+    }
+    plain, _ := Sign(payload, testSigner, WithPayloadEncryption(key, ""))
+    if _, err := VerifyHeader(write(plain), pub); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    if _, err := Sign(payload, testSigner, WithPublicHeader()); err == nil {
+        t.Fatal("header without encryption")
+    }
+}
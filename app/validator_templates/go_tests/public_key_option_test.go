@@ -0,0 +1,39 @@
+package main
+
+import (
+    "context"
+    "crypto/ed25519"
+    "crypto/rand"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestPublicKeyOption(t *testing.T) {
+    payload := LicensePayload{ID: "obj", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}
+    pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+    for _, c := range []struct {
+        sign func() (LicenseData, error)
+        key  any
+    }{
+        {func() (LicenseData, error) { return Sign(payload, testSigner) }, &testSigner.PublicKey},
+        {func() (LicenseData, error) { return SignWith(payload, priv) }, pub},
+    } {
+        d, err := c.sign()
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(d)
+        v, err := NewValidator(WithPublicKeyObject(c.key))
+        if err != nil {
+            t.Fatal(err)
+        }
+        if _, err := v.ValidateBytes(context.Background(), []byte(serverEncrypt(t, b))); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if _, err := NewValidator(WithPublicKeyObjects(pub, nil)); !errors.Is(err, ErrUnsupportedKey) {
+        t.Fatal(err)
+    }
+}
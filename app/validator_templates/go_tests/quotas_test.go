@@ -0,0 +1,24 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestQuotas(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["quotas"] = map[string]int{"projects": 5}
+    r, err := ValidateReport(writeSigned(t, lic), NewKeyRing(&testSigner.PublicKey))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if n, ok := r.Quota("projects"); !ok || n != 5 {
+        t.Fatal(n, ok)
+    }
+    if _, ok := r.Quota("calls"); ok {
+        t.Fatal()
+    }
+    if !r.WithinQuota("projects", 5) || r.WithinQuota("projects", 6) || !r.WithinQuota("calls", 1<<30) {
+        t.Fatal()
+    }
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "testing"
+    "time"
+)
+
+func TestRawPayload(t *testing.T) {
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": pssSign(t, testSigner, lb), "blob": "\x00\x01"})
+    r, err := ValidateReport(writeRaw(t, data), NewKeyRing(&testSigner.PublicKey))
+    if err != nil {
+        t.Fatal(err)
+    }
+    got := r.RawPayload()
+    if !bytes.Equal(got, data) {
+        t.Fatal(string(got))
+    }
+    got[0] = 'X'
+    if !bytes.Equal(r.RawPayload(), data) {
+        t.Fatal("aliased")
+    }
+}
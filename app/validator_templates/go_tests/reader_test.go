@@ -0,0 +1,25 @@
+package main
+
+import (
+    "bytes"
+    "errors"
+    "os"
+    "testing"
+    "time"
+)
+
+func TestValidateReader(t *testing.T) {
+    p := writeSigned(t, defaultLicense(time.Now(), 10))
+    data, _ := os.ReadFile(p)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if _, err := ValidateReader(bytes.NewReader(data), ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(data, ring); err != nil {
+        t.Fatal(err)
+    }
+    big := bytes.Repeat([]byte("a"), MaxLicenseSize+1)
+    if _, err := ValidateReader(bytes.NewReader(big), ring); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "crypto/ed25519"
+    "crypto/rand"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestReceipt(t *testing.T) {
+    pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+    v, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithReceiptSigner(priv), WithMachineID("m1"))
+    if err != nil {
+        t.Fatal(err)
+    }
+    r, err := v.ValidateContext(context.Background(), writeSigned(t, defaultLicense(time.Now(), 30)))
+    if err != nil {
+        t.Fatal(err)
+    }
+    b, err := v.IssueReceipt(r)
+    if err != nil {
+        t.Fatal(err)
+    }
+    rec, err := VerifyReceipt(b, pub)
+    if err != nil || rec.LicenseID != "lic-1" || rec.Outcome != OutcomeValid || rec.MachineID != "m1" || !rec.CheckedAt.Equal(r.CheckedAt.UTC().Round(0)) {
+        t.Fatal(err, rec)
+    }
+    tampered := bytes.Replace(b, []byte(`"valid"`), []byte(`"revoked"`), 1)
+    if _, err := VerifyReceipt(tampered, pub); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    other, _, _ := ed25519.GenerateKey(rand.Reader)
+    if _, err := VerifyReceipt(b, other); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := v.IssueReceipt(&Report{Payload: r.Payload, Preview: true}); err == nil {
+        t.Fatal("preview receipt")
+    }
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestRegion(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["allowed_regions"] = []string{"EU", "UK"}
+    p := writeSigned(t, lic)
+    for _, r := range []string{"EU", "uk"} {
+        if _, err := ValidateReport(p, ring, WithRegion(r)); err != nil {
+            t.Fatal(r, err)
+        }
+    }
+    if _, err := ValidateReport(p, ring, WithRegion("US")); !errors.Is(err, ErrRegionNotAllowed) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, WithRegion("US")); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestRenew(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    old := LicensePayload{ID: "ren", IssuedAt: time.Now().AddDate(0, 0, -10).UTC().Format(time.RFC3339), ValidityDays: 30}
+    d0, _ := Sign(old, testSigner)
+    newExp := time.Now().AddDate(0, 0, 60)
+    d1, err := Renew(old, newExp, testSigner)
+    if err != nil {
+        t.Fatal(err)
+    }
+    store, _ := NewFileStateStore(t.TempDir())
+    w := func(d LicenseData) []byte { b, _ := json.Marshal(d); return []byte(serverEncrypt(t, b)) }
+    v, err := NewValidator(WithKeyRing(ring), WithSerialStore(store))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if _, err := v.ValidateBytes(t.Context(), w(d0)); err != nil {
+        t.Fatal(err)
+    }
+    r, err := v.ValidateBytes(t.Context(), w(d1))
+    if err != nil || r.Payload.ID != "ren" || r.Payload.Serial != 1 || !r.ExpiresAt.Equal(newExp.UTC().Truncate(time.Second)) {
+        t.Fatal(r, err)
+    }
+    if _, err := v.ValidateBytes(t.Context(), w(d0)); !errors.Is(err, ErrStaleSerial) {
+        t.Fatal(err)
+    }
+    if _, err := Renew(old, time.Now().AddDate(0, 0, 5), testSigner); err == nil {
+        t.Fatal("earlier expiry accepted")
+    }
+    if _, err := Renew(LicensePayload{ID: "p", Perpetual: true}, newExp, testSigner); err == nil {
+        t.Fatal("perpetual renewed")
+    }
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestRequiredClaims(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["environment"] = "prod"
+    lic["machine_id"] = ""
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    _, err := ValidateReport(p, ring, WithEnvironment("prod"), WithRequiredClaims("environment", "machine_id", "expires_at"))
+    if !errors.Is(err, ErrRequiredClaimMissing) || !strings.Contains(err.Error(), "machine_id, expires_at") {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithEnvironment("prod"), WithRequiredClaims("environment", "tenant_id")); err != nil {
+        t.Fatal(err)
+    }
+    r, _ := Inspect(p, pubB64(t, &testSigner.PublicKey), WithRequiredClaims("machine_id"))
+    if f := r.Failed(); len(f) != 1 || f[0].Name != "required_claims" {
+        t.Fatal(f)
+    }
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestInvalidateLicense(t *testing.T) {
+    var revoked atomic.Bool
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        fmt.Fprintf(w, `{"revoked":%v}`, revoked.Load())
+    }))
+    defer srv.Close()
+    v, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithRevocationURL(srv.URL), WithResultCache(NewResultCache(time.Hour)))
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    ctx := context.Background()
+    if _, err := v.ValidateContext(ctx, p); err != nil {
+        t.Fatal(err)
+    }
+    revoked.Store(true)
+    if _, err := v.ValidateContext(ctx, p); err != nil {
+        t.Fatal("expected cached result", err)
+    }
+    v.InvalidateLicense("other")
+    if _, err := v.ValidateContext(ctx, p); err != nil {
+        t.Fatal("expected cached result", err)
+    }
+    v.InvalidateLicense("lic-1")
+    if _, err := v.ValidateContext(ctx, p); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    revoked.Store(false)
+    v.ValidateContext(ctx, p)
+    revoked.Store(true)
+    v.ClearCache()
+    if _, err := v.ValidateContext(ctx, p); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+}
+
+func TestCacheStalePut(t *testing.T) {
+    c := NewResultCache(time.Hour)
+    gen := c.generation()
+    c.invalidate("")
+    c.put([32]byte{1}, &Report{Payload: &LicensePayload{ID: "x"}, CheckedAt: time.Now()}, gen)
+    if c.get([32]byte{1}, time.Now()) != nil {
+        t.Fatal("stale put stored")
+    }
+}
+
+func TestJWKSInvalidate(t *testing.T) {
+    var fetches atomic.Int32
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        fetches.Add(1)
+        fmt.Fprint(w, `{"keys":[]}`)
+    }))
+    defer srv.Close()
+    j := NewJWKS(srv.URL, time.Hour)
+    j.client = srv.Client()
+    v, _ := NewValidator(WithKeySource(j))
+    j.PublicKey(context.Background(), "a")
+    j.PublicKey(context.Background(), "a")
+    n := fetches.Load()
+    v.ClearCache()
+    j.PublicKey(context.Background(), "a")
+    if fetches.Load() != n+1 {
+        t.Fatal(n, fetches.Load())
+    }
+}
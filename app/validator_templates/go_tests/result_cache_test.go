@@ -0,0 +1,51 @@
+package main
+
+import (
+    "sync"
+    "testing"
+    "time"
+)
+
+func TestResultCache(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    c := NewResultCache(time.Hour)
+    clk := &fixedClock{issued.Add(time.Hour)}
+    if _, err := ValidateReport(p, ring, WithResultCache(c), WithClock(clk)); err != nil {
+        t.Fatal(err)
+    }
+    var wg sync.WaitGroup
+    for i := 0; i < 20; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            if _, err := ValidateReport(p, ring, WithResultCache(c), WithClock(clk)); err != nil {
+                t.Error(err)
+            }
+        }()
+    }
+    wg.Wait()
+    clk2 := &fixedClock{issued.AddDate(0, 0, 31)}
+    c2 := NewResultCache(1000 * time.Hour)
+    ValidateReport(p, ring, WithResultCache(c2), WithClock(clk))
+    if _, err := ValidateReport(p, ring, WithResultCache(c2), WithClock(clk2)); err == nil {
+        t.Fatal("expected expiry")
+    }
+}
+
+func BenchmarkResultCache(b *testing.B) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(b, defaultLicense(time.Now(), 30))
+    b.Run("uncached", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            ValidateReport(p, ring)
+        }
+    })
+    c := NewResultCache(time.Hour)
+    b.Run("cached", func(b *testing.B) {
+        for i := 0; i < b.N; i++ {
+            ValidateReport(p, ring, WithResultCache(c))
+        }
+    })
+}
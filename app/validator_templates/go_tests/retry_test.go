@@ -0,0 +1,46 @@
+package main
+
+import (
+    "context"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestRetry(t *testing.T) {
+    var n atomic.Int32
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        if n.Add(1) <= 2 {
+            w.WriteHeader(503)
+            return
+        }
+        w.Write([]byte(`{"revoked":true}`))
+    }))
+    defer srv.Close()
+    cfg := onlineConfig{client: srv.Client(), attempts: 3, base: time.Millisecond}
+    revoked, err := fetchRevocation(context.Background(), cfg, srv.URL, "x", false)
+    if err != nil || revoked != "revoked" || n.Load() != 3 {
+        t.Fatal(err, n.Load())
+    }
+    n.Store(0)
+    bad := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { n.Add(1); w.WriteHeader(400) }))
+    defer bad.Close()
+    if _, err := fetchRevocation(context.Background(), onlineConfig{client: bad.Client(), attempts: 5, base: time.Millisecond}, bad.URL, "x", false); err == nil || n.Load() != 1 {
+        t.Fatal(err, n.Load())
+    }
+    n.Store(-100)
+    ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+    defer cancel()
+    start := time.Now()
+    if _, err := fetchRevocation(ctx, onlineConfig{client: srv.Client(), attempts: 10, base: time.Second}, srv.URL, "x", false); err == nil || time.Since(start) > time.Second {
+        t.Fatal(err)
+    }
+    // end to end through options
+    n.Store(0)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateReport(p, NewKeyRing(&testSigner.PublicKey), WithRevocationURL(srv.URL), WithRetry(3, time.Millisecond)); err == nil {
+        t.Fatal("want revoked")
+    }
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func signedFilter(t *testing.T, body []byte) []byte {
+    out, _ := json.Marshal(map[string]any{"filter": json.RawMessage(body), "signature": pssSign(t, testSigner, body)})
+    return out
+}
+
+func TestRevocationFilter(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    var ids []string
+    for i := 0; i < 1000; i++ {
+        ids = append(ids, fmt.Sprintf("rev-%d", i))
+    }
+    body, err := BuildRevocationFilter(append(ids, "lic-1"), 0.01, time.Now())
+    if err != nil {
+        t.Fatal(err)
+    }
+    f, err := ParseRevocationFilter(signedFilter(t, body), ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    for _, id := range ids {
+        if !f.MayContain(id) {
+            t.Fatal("miss", id)
+        }
+    }
+    fp := 0
+    var notRevoked, falsePos string
+    for i := 0; i < 10000; i++ {
+        id := fmt.Sprintf("ok-%d", i)
+        if f.MayContain(id) {
+            fp++
+            falsePos = id
+        } else if notRevoked == "" {
+            notRevoked = id
+        }
+    }
+    if fp > 300 || falsePos == "" {
+        t.Fatal("fp", fp)
+    }
+    lic := defaultLicense(time.Now(), 30)
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring, WithRevocationFilter(f)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    lic["id"] = notRevoked
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithRevocationFilter(f), WithRevocationURL("http://127.0.0.1:1/never")); err != nil {
+        t.Fatal(err)
+    }
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        var b map[string]string
+        json.NewDecoder(r.Body).Decode(&b)
+        json.NewEncoder(w).Encode(map[string]bool{"revoked": b["license_id"] == "lic-1"})
+    }))
+    defer srv.Close()
+    lic["id"] = falsePos
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithRevocationFilter(f), WithRevocationURL(srv.URL)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithRevocationFilter(f)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithRevocationFilter(f), WithRevocationURL(srv.URL)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    var fb map[string]any
+    json.Unmarshal(body, &fb)
+    fb["hashes"] = 0
+    forged, _ := json.Marshal(fb)
+    if _, err := ParseRevocationFilter(signedFilter(t, forged), ring); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    tampered := signedFilter(t, body)
+    var tf map[string]json.RawMessage
+    json.Unmarshal(tampered, &tf)
+    tf["filter"] = forged
+    tb, _ := json.Marshal(tf)
+    if _, err := ParseRevocationFilter(tb, ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func signedCRL(t *testing.T, ids ...string) []byte {
+    body, _ := json.Marshal(map[string]any{"issued_at": time.Now().Format(time.RFC3339), "revoked_ids": ids})
+    out, _ := json.Marshal(map[string]any{"revocations": json.RawMessage(body), "signature": pssSign(t, testSigner, body)})
+    return out
+}
+
+func TestRevocationList(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    crl, err := ParseRevocationList(signedCRL(t, "lic-1", "x"), ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    if _, err := ValidateReport(p, ring, WithRevocationList(crl)); !errors.Is(err, ErrLicenseRevoked) {
+        t.Fatal(err)
+    }
+    crl2, _ := ParseRevocationList(signedCRL(t, "other"), ring)
+    if _, err := ValidateReport(p, ring, WithRevocationList(crl2)); err != nil {
+        t.Fatal(err)
+    }
+    tampered := signedCRL(t, "other")
+    tampered[len(tampered)/3] ^= 0
+    var f map[string]json.RawMessage
+    json.Unmarshal(tampered, &f)
+    f["revocations"] = json.RawMessage(`{"revoked_ids":[]}`)
+    tb, _ := json.Marshal(f)
+    if _, err := ParseRevocationList(tb, ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+    "crypto/ecdsa"
+    "crypto/elliptic"
+    "crypto/rand"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestRevokedKeys(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    fp, _ := PublicKeyFingerprint(&testSigner.PublicKey)
+    other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+    ofp, _ := PublicKeyFingerprint(&other.PublicKey)
+    if _, err := ValidateReport(p, ring, WithRevokedKeys([]string{ofp})); err != nil {
+        t.Fatal(err)
+    }
+    _, err := ValidateReport(p, ring, WithRevokedKeys([]string{ofp, fp}))
+    var ve *ValidationError
+    if !errors.Is(err, ErrSigningKeyRevoked) || !errors.As(err, &ve) || ve.Reason != ReasonPublicKey {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithKeyRing(ring), WithRevokedKeys([]string{"abc"})); err == nil {
+        t.Fatal("bad fingerprint accepted")
+    }
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+    "errors"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestClockRollback(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    store := FileTimeStore(filepath.Join(t.TempDir(), "seen"))
+    clk := &movingClock{t: issued.AddDate(0, 0, 20)}
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(clk), WithTrustedTime(store))
+    if _, err := v.Validate(p); err != nil {
+        t.Fatal(err)
+    }
+    if last, _ := store.LastSeen(); !last.Equal(clk.Now()) {
+        t.Fatal(last)
+    }
+    clk.set(issued.AddDate(0, 0, 5))
+    if _, err := v.Validate(p); !errors.Is(err, ErrClockRollback) {
+        t.Fatal(err)
+    }
+    clk.set(issued.AddDate(0, 0, 25))
+    if _, err := v.Validate(p); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestRollingValidity(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 0)
+    delete(lic, "validity_days")
+    lic["valid_for"] = "30d"
+    p := writeSigned(t, lic)
+    dir := t.TempDir()
+    run := func(at time.Time) (*Report, error) {
+        store, _ := NewFileStateStore(dir)
+        v, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(&fixedClock{at}), WithFirstUseStore(store))
+        if err != nil {
+            t.Fatal(err)
+        }
+        return v.Validate(p)
+    }
+    first := issued.AddDate(0, 2, 0)
+    r, err := run(first)
+    if err != nil || !r.ExpiresAt.Equal(first.AddDate(0, 0, 30)) {
+        t.Fatal(err, r)
+    }
+    r, err = run(first.AddDate(0, 0, 10))
+    if err != nil || !r.ExpiresAt.Equal(first.AddDate(0, 0, 30)) {
+        t.Fatal(err, r)
+    }
+    if _, err := run(first.AddDate(0, 0, 31)); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    if _, err := run(first.AddDate(0, 0, -1)); !errors.Is(err, ErrClockRollback) {
+        t.Fatal(err)
+    }
+    files, _ := filepath.Glob(filepath.Join(dir, "*"))
+    os.WriteFile(files[0], []byte("2027-01-01T00:00:00Z\nab"), 0o600)
+    if _, err := run(first.AddDate(0, 0, 1)); !errors.Is(err, ErrTrustedTime) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, NewKeyRing(&testSigner.PublicKey)); !errors.Is(err, ErrMissingExpiration) {
+        t.Fatal(err)
+    }
+    // A sooner fixed expiry still applies.
+    lic["expires_at"] = first.AddDate(0, 0, 5).Format(time.RFC3339)
+    lic["id"] = "lic-2"
+    p = writeSigned(t, lic)
+    if r, err := run(first); err != nil || !r.ExpiresAt.Equal(first.AddDate(0, 0, 5)) {
+        t.Fatal(err, r)
+    }
+    lic["valid_for"] = "-3h"
+    p = writeSigned(t, lic)
+    if _, err := run(first); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestMinRSABits(t *testing.T) {
+    payload := LicensePayload{ID: "k", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}
+    for _, c := range []struct {
+        bits int
+        opts []Option
+        want error
+    }{
+        {1024, nil, ErrWeakKey},
+        {1024, []Option{WithMinRSABits(1024)}, nil},
+        {4096, nil, nil},
+        {4096, []Option{WithMinRSABits(8192)}, ErrWeakKey},
+    } {
+        k, _ := rsa.GenerateKey(rand.Reader, c.bits)
+        d, _ := Sign(payload, k)
+        sealed, opt := SealTestLicense(t, d)
+        _, err := ValidateBytes(sealed, NewKeyRing(&k.PublicKey), append(c.opts, opt)...)
+        if c.want == nil && err != nil || c.want != nil && !errors.Is(err, c.want) {
+            t.Fatal(c.bits, err)
+        }
+    }
+    if _, err := NewValidator(WithMinRSABits(0)); err == nil {
+        t.Fatal("zero accepted")
+    }
+}
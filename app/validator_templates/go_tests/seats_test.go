@@ -0,0 +1,24 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSeatLimit(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["max_seats"] = 10
+    p := writeSigned(t, lic)
+    r, err := ValidateReport(p, ring, WithActiveSeats(10))
+    if err != nil || r.SeatLimit != 10 || r.SeatsInUse != 10 {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithActiveSeats(11)); !errors.Is(err, ErrSeatLimitExceeded) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(writeSigned(t, defaultLicense(time.Now(), 30)), ring, WithActiveSeats(1000)); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSentinelErrors(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    other, _ := rsa.GenerateKey(rand.Reader, 2048)
+    noExp := defaultLicense(time.Now(), 0)
+    badExp := defaultLicense(time.Now(), 30)
+    badExp["expires_at"] = "soon"
+    cases := []struct {
+        name string
+        path string
+        key  string
+        want error
+    }{
+        {"expired", writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)), key, ErrLicenseExpired},
+        {"badsig", writeSigned(t, defaultLicense(time.Now(), 30)), pubB64(t, &other.PublicKey), ErrSignatureInvalid},
+        {"malformed", writeRaw(t, []byte("{not json")), key, ErrMalformedLicense},
+        {"noexp", writeSigned(t, noExp), key, ErrMissingExpiration},
+        {"badexp", writeSigned(t, badExp), key, ErrMalformedLicense},
+        {"badkey", writeSigned(t, defaultLicense(time.Now(), 30)), "AAAA", ErrUnsupportedKey},
+    }
+    for _, c := range cases {
+        _, err := Validate(c.path, c.key)
+        if !errors.Is(err, c.want) {
+            t.Errorf("%s: got %v want %v", c.name, err, c.want)
+        }
+    }
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+type mapStore map[string][]byte
+
+func (m mapStore) Get(k string) ([]byte, error)   { return m[k], nil }
+func (m mapStore) Set(k string, v []byte) error { m[k] = v; return nil }
+
+func TestSerial(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    w := func(p LicensePayload) []byte {
+        d, _ := Sign(p, testSigner)
+        b, _ := json.Marshal(d)
+        return []byte(serverEncrypt(t, b))
+    }
+    base := LicensePayload{ID: "a", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 30}
+    store := mapStore{}
+    v, _ := NewValidator(WithKeyRing(ring), WithSerialStore(store))
+    p2 := base
+    p2.Serial = 2
+    if _, err := v.ValidateBytes(t.Context(), w(p2)); err != nil {
+        t.Fatal(err)
+    }
+    p1 := base
+    p1.Serial = 1
+    if _, err := v.ValidateBytes(t.Context(), w(p1)); !errors.Is(err, ErrStaleSerial) {
+        t.Fatal(err)
+    }
+    if _, err := v.ValidateBytes(t.Context(), w(p2)); err != nil {
+        t.Fatal(err)
+    }
+    // Copying another license's record over is detected.
+    b := base
+    b.ID = "b"
+    if _, err := v.ValidateBytes(t.Context(), w(b)); err != nil {
+        t.Fatal(err)
+    }
+    store["serial/a"] = store["serial/b"]
+    if _, err := v.ValidateBytes(t.Context(), w(p2)); !errors.Is(err, ErrStaleSerial) {
+        t.Fatal(err)
+    }
+    store["serial/a"] = []byte("0\nzz")
+    if _, err := v.ValidateBytes(t.Context(), w(p2)); !errors.Is(err, ErrStaleSerial) {
+        t.Fatal(err)
+    }
+}
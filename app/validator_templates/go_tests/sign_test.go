@@ -0,0 +1,36 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestSign(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    for _, alg := range []string{AlgPS256, AlgPS512, AlgRS384} {
+        d, err := Sign(LicensePayload{ID: "x", IssuedAt: time.Now().UTC().Format(time.RFC3339), ValidityDays: 3}, testSigner, WithSigningAlgorithm(alg))
+        if err != nil {
+            t.Fatal(err)
+        }
+        b, _ := json.Marshal(d)
+        env := serverEncrypt(t, b)
+        p := filepath.Join(t.TempDir(), "l")
+        os.WriteFile(p, []byte(env), 0o600)
+        if _, err := ValidateReport(p, ring, allowRS); err != nil {
+            t.Fatal(alg, err)
+        }
+        d.License = []byte(strings.Replace(string(d.License), `"x"`, `"y"`, 1))
+        b, _ = json.Marshal(d)
+        if _, err := ValidateBytes([]byte(serverEncrypt(t, b)), ring, allowRS); !errors.Is(err, ErrSignatureInvalid) {
+            t.Fatal("tampered accepted")
+        }
+    }
+    if _, err := Sign(LicensePayload{}, testSigner, WithSigningAlgorithm(AlgES256)); !errors.Is(err, ErrUnsupportedAlgorithm) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+    "bytes"
+    "encoding/base64"
+    "encoding/hex"
+    "testing"
+)
+
+func TestSignatureEncodings(t *testing.T) {
+    sig := []byte{0xfb, 0xff, 0x01, 0x02, 0x03}
+    for _, in := range []string{base64.StdEncoding.EncodeToString(sig), base64.URLEncoding.EncodeToString(sig), base64.RawURLEncoding.EncodeToString(sig)} {
+        b, err := decodeSignature(in, "")
+        if err != nil || !bytes.Equal(b, sig) {
+            t.Fatal(in, err)
+        }
+    }
+    if b, err := decodeSignature(hex.EncodeToString(sig), "hex"); err != nil || !bytes.Equal(b, sig) {
+        t.Fatal(err)
+    }
+    // Base64 made only of hex digits is still base64.
+    digits := []byte{0xd3, 0x4d, 0x34, 0xd3, 0x4d, 0x34}
+    enc := base64.StdEncoding.EncodeToString(digits)
+    if _, err := hex.DecodeString(enc); err != nil {
+        t.Fatal("fixture is not hex-shaped", enc)
+    }
+    if b, err := decodeSignature(enc, ""); err != nil || !bytes.Equal(b, digits) {
+        t.Fatal(enc, b, err)
+    }
+    if _, err := decodeSignature("!!", ""); err == nil {
+        t.Fatal("want error")
+    }
+    if _, err := decodeSignature("zz", "hex"); err == nil {
+        t.Fatal("want error")
+    }
+}
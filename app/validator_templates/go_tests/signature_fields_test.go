@@ -0,0 +1,43 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSignatureFields(t *testing.T) {
+    k, _ := NewTestKeypair(AlgES256)
+    p := LicensePayload{ID: "f", MachineID: "m1", ExpiresAt: time.Now().AddDate(0, 1, 0).UTC().Format(time.RFC3339), Payload: map[string]any{"plan": "pro"}}
+    fields := []string{"payload", "expires_at", "machine_id"}
+    content, opt := SealTestLicense(t, SignTestLicense(t, k, p, WithSignedFields(fields...)))
+    if _, err := ValidateBytes(content, k.KeyRing(), opt, WithMachineID("m1"), WithSignatureFields(fields...)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(content, k.KeyRing(), opt, WithMachineID("m1")); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(content, k.KeyRing(), opt, WithMachineID("m1"), WithSignatureFields("expires_at", "payload", "machine_id")); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    // An unsigned field edited after signing is dropped, not honoured.
+    data := SignTestLicense(t, k, p, WithSignedFields(fields...))
+    var claims map[string]any
+    json.Unmarshal(data.License, &claims)
+    claims["features"] = map[string]any{"admin": map[string]any{"enabled": true}}
+    claims["max_seats"] = 1000
+    data.License, _ = json.Marshal(claims)
+    content, opt = SealTestLicense(t, data)
+    got, err := ValidateBytes(content, k.KeyRing(), opt, WithMachineID("m1"), WithSignatureFields(fields...))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if len(got.Payload.Features) != 0 || got.Payload.MaxSeats != 0 || got.Payload.Payload.(map[string]any)["plan"] != "pro" {
+        t.Fatalf("%+v", got)
+    }
+    in, _ := SignatureInput([]byte(`{"machine_id":"m1","expires_at":"2027-01-01T00:00:00Z","payload":{"plan":"pro"}}`), fields...)
+    if string(in) != `{"plan":"pro"}"2027-01-01T00:00:00Z""m1"` {
+        t.Fatal(string(in))
+    }
+}
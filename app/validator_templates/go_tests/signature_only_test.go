@@ -0,0 +1,28 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSignatureOnly(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    expired := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30))
+    if _, err := Validate(expired, key); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    crl, _ := ParseRevocationList(signedCRL(t, "lic-1"), NewKeyRing(&testSigner.PublicKey))
+    p, err := VerifySignatureOnly(expired, key, WithRevocationList(crl))
+    if err != nil || p.ID != "lic-1" {
+        t.Fatal(err)
+    }
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    sig := pssSign(t, testSigner, lb)
+    lic := defaultLicense(time.Now(), 3000)
+    tampered := writeEnvelope(t, lic, func([]byte) string { return sig }, nil)
+    if _, err := VerifySignatureOnly(tampered, key); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSignatureStrictness(t *testing.T) {
+    k2, _ := rsa.GenerateKey(rand.Reader, 2048)
+    ring := NewKeyRing(&testSigner.PublicKey, &k2.PublicKey)
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    junk := base64.StdEncoding.EncodeToString(make([]byte, 256))
+    b, _ := json.Marshal(LicenseData{License: lb, Signatures: []Cosignature{{Signature: pssSign(t, testSigner, lb)}, {Signature: pssSign(t, k2, lb)}, {Signature: junk}}})
+    lic := []byte(serverEncrypt(t, b))
+    if _, err := ValidateBytes(lic, ring, WithRequiredSignatures(2)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(lic, ring, WithRequiredSignatures(2), WithSignatureStrictness(Lenient)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(lic, ring, WithRequiredSignatures(2), WithSignatureStrictness(Strict)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    b, _ = json.Marshal(LicenseData{License: lb, Signatures: []Cosignature{{Signature: pssSign(t, testSigner, lb)}, {Signature: pssSign(t, k2, lb)}}})
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, b)), ring, WithRequiredSignatures(2), WithSignatureStrictness(Strict)); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+    "bytes"
+    "compress/gzip"
+    "crypto/rand"
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSignedForm(t *testing.T) {
+    key := make([]byte, 32)
+    rand.Read(key)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-1", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    for mask := 0; mask < 8; mask++ {
+        var opts []SignOption
+        if mask&1 != 0 {
+            opts = append(opts, WithCanonicalJSON())
+        }
+        if mask&2 != 0 {
+            opts = append(opts, WithCompression())
+        }
+        if mask&4 != 0 {
+            opts = append(opts, WithPayloadEncryption(key, "base64"))
+        }
+        d, err := Sign(payload, testSigner, opts...)
+        if err != nil {
+            t.Fatal(mask, err)
+        }
+        file := func() []byte {
+            b, _ := json.Marshal(d)
+            if mask&4 == 0 {
+                return []byte(serverEncrypt(t, b))
+            }
+            return b
+        }
+        vopts := []Option{}
+        if mask&4 != 0 {
+            vopts = append(vopts, WithDecryptionKey(key))
+        }
+        if _, err := ValidateBytes(file(), ring, vopts...); err != nil {
+            t.Fatal(mask, err)
+        }
+        // Flip the compressed flag: verification must fail.
+        d.Compressed = !d.Compressed
+        if _, err := ValidateBytes(file(), ring, vopts...); err == nil {
+            t.Fatal(mask, "flipped compressed accepted")
+        }
+    }
+    if _, err := Sign(payload, testSigner, WithCompression(), WithSignedFields("id")); err == nil {
+        t.Fatal("fields+compression")
+    }
+
+    // Old order: signature over the decompressed bytes.
+    raw, _ := json.Marshal(payload)
+    var buf bytes.Buffer
+    zw := gzip.NewWriter(&buf)
+    zw.Write(raw)
+    zw.Close()
+    enc, _ := json.Marshal(base64.StdEncoding.EncodeToString(buf.Bytes()))
+    old, _ := json.Marshal(LicenseData{License: enc, Signature: pssSign(t, testSigner, raw), Compressed: true})
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, old)), ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+
+    // Compressed before canonicalizing: marked canonical, not canonical.
+    pretty := []byte("{ \"id\": \"lic-1\", \"expires_at\": \"" + payload.ExpiresAt + "\" }")
+    buf.Reset()
+    zw = gzip.NewWriter(&buf)
+    zw.Write(pretty)
+    zw.Close()
+    s := base64.StdEncoding.EncodeToString(buf.Bytes())
+    enc, _ = json.Marshal(s)
+    bad, _ := json.Marshal(LicenseData{License: enc, Signature: pssSign(t, testSigner, []byte(s)), Compressed: true, Canonical: true})
+    if _, err := ValidateBytes([]byte(serverEncrypt(t, bad)), ring); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    // Sealed then compressed flag mismatch: sealed plain JSON marked compressed.
+    sealed, _ := SealLicense(raw, key)
+    bad, _ = json.Marshal(LicenseData{EncryptedPayload: sealed, Signature: pssSign(t, testSigner, []byte(sealed)), Compressed: true})
+    if _, err := ValidateBytes(bad, ring, WithDecryptionKey(key)); err == nil {
+        t.Fatal("mismatch accepted")
+    }
+}
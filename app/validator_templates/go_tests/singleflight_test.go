@@ -0,0 +1,46 @@
+package main
+
+import (
+    "encoding/base64"
+    "fmt"
+    "math/big"
+    "net/http"
+    "net/http/httptest"
+    "sync"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestSingleflight(t *testing.T) {
+    b := base64.RawURLEncoding.EncodeToString
+    var hits atomic.Int32
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        hits.Add(1)
+        time.Sleep(100 * time.Millisecond)
+        fmt.Fprintf(w, `{"keys":[{"kty":"RSA","kid":"r1","n":%q,"e":%q}]}`, b(testSigner.N.Bytes()), b(big.NewInt(int64(testSigner.E)).Bytes()))
+    }))
+    defer srv.Close()
+    src := NewJWKS(srv.URL, time.Minute)
+    p := writeEnvelope(t, defaultLicense(time.Now(), 30), func(bb []byte) string { return pssSign(t, testSigner, bb) }, map[string]any{"kid": "r1"})
+    var wg sync.WaitGroup
+    errs := make(chan error, 20)
+    for i := 0; i < 20; i++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            _, err := ValidateKeyRing(p, nil, WithKeySource(src), WithHTTPClient(srv.Client()))
+            errs <- err
+        }()
+    }
+    wg.Wait()
+    close(errs)
+    for err := range errs {
+        if err != nil {
+            t.Fatal(err)
+        }
+    }
+    if hits.Load() != 1 {
+        t.Fatal(hits.Load())
+    }
+}
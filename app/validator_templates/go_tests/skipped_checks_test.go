@@ -0,0 +1,29 @@
+package main
+
+import (
+    "fmt"
+    "net/http"
+    "net/http/httptest"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestSkippedChecks(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    r, err := ValidateReport(p, ring)
+    if err != nil || !slices.Contains(r.SkippedChecks(), "revocation_online") {
+        t.Fatal(err, r.SkippedChecks())
+    }
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) { fmt.Fprint(w, `{"revoked":false}`) }))
+    defer srv.Close()
+    r, err = ValidateReport(p, ring, WithRevocationURL(srv.URL))
+    if err != nil || slices.Contains(r.SkippedChecks(), "revocation_online") {
+        t.Fatal(err, r.SkippedChecks())
+    }
+    ir, _ := Inspect(p, pubB64(t, &testSigner.PublicKey))
+    if !slices.Contains(ir.SkippedChecks(), "revocation_online") {
+        t.Fatal(ir.SkippedChecks())
+    }
+}
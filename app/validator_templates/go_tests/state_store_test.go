@@ -0,0 +1,47 @@
+package main
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestStateStore(t *testing.T) {
+    dir := t.TempDir()
+    s, err := NewFileStateStore(dir)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if v, err := s.Get("../x"); v != nil || err != nil {
+        t.Fatal(v, err)
+    }
+    s.Set("../x", []byte("one"))
+    // A crash mid-write leaves a stray temp file; the value is untouched.
+    os.WriteFile(filepath.Join(dir, "."+filepath.Base(s.path("../x"))+".123.tmp"), []byte("tw"), 0o600)
+    if v, _ := s.Get("../x"); !bytes.Equal(v, []byte("one")) {
+        t.Fatal(string(v))
+    }
+    s.Set("../x", []byte("two"))
+    if v, _ := s.Get("../x"); !bytes.Equal(v, []byte("two")) {
+        t.Fatal(string(v))
+    }
+    ents, _ := os.ReadDir(filepath.Dir(dir))
+    for _, e := range ents {
+        if e.Name() == "x" {
+            t.Fatal("escaped dir")
+        }
+    }
+    ns := StateNonceStore(s)
+    ns.Record("n1", time.Now().Add(time.Hour))
+    if !StateNonceStore(s).Seen("n1") || StateNonceStore(s).Seen("n2") {
+        t.Fatal()
+    }
+    ts := StateTimeStore(s)
+    now := time.Now().Truncate(time.Second)
+    ts.Save(now)
+    if got, _ := StateTimeStore(s).LastSeen(); !got.Equal(now) {
+        t.Fatal(got)
+    }
+}
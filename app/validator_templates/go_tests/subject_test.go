@@ -0,0 +1,24 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestSubject(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    anyone := writeSigned(t, lic)
+    lic["subject"] = "alice@example.com"
+    named := writeSigned(t, lic)
+    if _, err := ValidateReport(named, ring, WithExpectedSubject("alice@example.com")); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(named, ring, WithExpectedSubject("bob@example.com")); !errors.Is(err, ErrSubjectMismatch) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(anyone, ring, WithExpectedSubject("bob@example.com")); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+    "context"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "sync/atomic"
+    "testing"
+    "time"
+)
+
+func TestSuspended(t *testing.T) {
+    var body atomic.Value
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+        w.Write([]byte(body.Load().(string)))
+    }))
+    defer srv.Close()
+    m := NewMetrics()
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithRevocationURL(srv.URL), WithResultCache(NewResultCache(time.Hour)), WithMetrics(m))
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    body.Store(`{"status":"suspended"}`)
+    _, err := v.Validate(p)
+    var verr *ValidationError
+    if !errors.Is(err, ErrLicenseSuspended) || errors.Is(err, ErrLicenseRevoked) || !errors.As(err, &verr) || verr.Reason != ReasonSuspended || stateOf(nil, err) != StateSuspended {
+        t.Fatal(err)
+    }
+    body.Store(`{"status":"active"}`)
+    if _, err := v.Validate(p); err != nil {
+        t.Fatal(err)
+    }
+    if m.Count(OutcomeSuspended) != 1 || m.Count(OutcomeValid) != 1 {
+        t.Fatal(m.counts)
+    }
+    for _, b := range []string{`{"status":"revoked"}`, `{"revoked":true,"status":"active"}`} {
+        body.Store(b)
+        if _, err := ValidateReport(p, NewKeyRing(&testSigner.PublicKey), WithRevocationURL(srv.URL)); !errors.Is(err, ErrLicenseRevoked) {
+            t.Fatal(b, err)
+        }
+    }
+    body.Store(`{"status":"weird"}`)
+    if _, err := ValidateReport(p, NewKeyRing(&testSigner.PublicKey), WithRevocationURL(srv.URL)); !errors.Is(err, ErrRevocationCheck) {
+        t.Fatal(err)
+    }
+    body.Store(`{"revoked":false}`)
+    if _, err := ValidateContext(context.Background(), p, NewKeyRing(&testSigner.PublicKey), WithRevocationURL(srv.URL)); err != nil {
+        t.Fatal(err)
+    }
+}
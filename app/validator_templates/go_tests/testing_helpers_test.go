@@ -0,0 +1,34 @@
+package main
+
+import (
+    "crypto"
+    "testing"
+    "time"
+)
+
+func TestTestingHelpers(t *testing.T) {
+    for _, alg := range []string{"", AlgPS256, AlgPS384, AlgPS512, AlgRS256, AlgRS384, AlgRS512, AlgES256, AlgES384, AlgEdDSA} {
+        k, err := NewTestKeypair(alg)
+        if err != nil {
+            t.Fatal(alg, err)
+        }
+        p := LicensePayload{ID: "t", IssuedAt: time.Now().Format(time.RFC3339), ValidityDays: 1}
+        content, opt := SealTestLicense(t, SignTestLicense(t, k, p))
+        if _, err := ValidateBytes(content, k.KeyRing(), opt, allowRS); err != nil {
+            t.Fatal(alg, err)
+        }
+        ring, err := ParseKeyRing(k.PublicKey)
+        if err != nil {
+            t.Fatal(err)
+        }
+        if key, err := decodePublicKey([]byte(k.PublicKeyPEM)); err != nil || !k.Signer.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(key) {
+            t.Fatal(err)
+        }
+        if _, err := ValidateBytes(content, ring, opt, allowRS); err != nil {
+            t.Fatal(alg, err)
+        }
+    }
+    if _, err := NewTestKeypair("HS256"); err == nil {
+        t.Fatal("HS256")
+    }
+}
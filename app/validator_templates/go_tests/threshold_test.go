@@ -0,0 +1,41 @@
+package main
+
+import (
+    "crypto/rand"
+    "crypto/rsa"
+    "encoding/json"
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestThresholdSignatures(t *testing.T) {
+    k2, _ := rsa.GenerateKey(rand.Reader, 2048)
+    k3, _ := rsa.GenerateKey(rand.Reader, 2048)
+    outsider, _ := rsa.GenerateKey(rand.Reader, 2048)
+    ring := NewKeyRing(&testSigner.PublicKey, &k2.PublicKey, &k3.PublicKey)
+    lb, _ := json.Marshal(defaultLicense(time.Now(), 30))
+    mk := func(signers ...*rsa.PrivateKey) []byte {
+        var cs []Cosignature
+        for _, k := range signers {
+            cs = append(cs, Cosignature{Signature: pssSign(t, k, lb)})
+        }
+        b, _ := json.Marshal(LicenseData{License: lb, Signatures: cs})
+        return []byte(serverEncrypt(t, b))
+    }
+    if _, err := ValidateBytes(mk(testSigner, k3), ring, WithRequiredSignatures(2)); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(k2), ring, WithRequiredSignatures(2)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(k2, k2), ring, WithRequiredSignatures(2)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(k2, outsider), ring, WithRequiredSignatures(2)); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateBytes(mk(outsider, k2), ring); err != nil {
+        t.Fatal(err)
+    }
+}
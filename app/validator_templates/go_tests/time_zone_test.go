@@ -0,0 +1,31 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestTimeZonePolicy(t *testing.T) {
+    tokyo := time.FixedZone("JST", 9*3600)
+    now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+    lic := defaultLicense(now.AddDate(0, 0, -1), 0)
+    delete(lic, "validity_days")
+    // 20:30 in Tokyo is 11:30 UTC, half an hour before now.
+    lic["expires_at"] = "2026-03-01T20:30:00+09:00"
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    if _, err := ValidateReport(p, ring, WithClock(&fixedClock{now.In(tokyo)})); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    r, err := ValidateReport(p, ring, WithClock(&fixedClock{now.Add(-time.Hour).In(tokyo)}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if r.CheckedAt.Location() != time.UTC || r.ExpiresAt.Location() != time.UTC || !r.ExpiresAt.Equal(time.Date(2026, 3, 1, 11, 30, 0, 0, time.UTC)) {
+        t.Fatal(r.CheckedAt, r.ExpiresAt)
+    }
+    if r.TimeRemaining() != 30*time.Minute {
+        t.Fatal(r.TimeRemaining())
+    }
+}
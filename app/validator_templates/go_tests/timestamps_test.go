@@ -0,0 +1,37 @@
+package main
+
+import (
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestTimestamps(t *testing.T) {
+    for v, want := range map[string]string{
+        "2024-01-02T03:04:05.123456789Z": "2024-01-02T03:04:05.123456789Z",
+        "2024-01-02T08:34:05+05:30":      "2024-01-02T03:04:05Z",
+        "2024-01-02t03:04:05z":           "2024-01-02T03:04:05Z",
+        "2016-12-31T23:59:60Z":           "2017-01-01T00:00:00Z",
+    } {
+        got, err := parseTimestamp("expires_at", v)
+        if err != nil || got.Location() != time.UTC || got.Format(time.RFC3339Nano) != want {
+            t.Fatal(v, got, err)
+        }
+    }
+    _, err := parseTimestamp("not_before", "2024-13-02T03:04:05Z")
+    if !errors.Is(err, ErrMalformedLicense) || !strings.Contains(err.Error(), `not_before "2024-13-02T03:04:05Z"`) {
+        t.Fatal(err)
+    }
+    lic := defaultLicense(time.Now(), 30)
+    delete(lic, "validity_days")
+    lic["expires_at"] = time.Now().Add(time.Hour).In(time.FixedZone("x", -7*3600)).Format("2006-01-02T15:04:05.999-07:00")
+    lic["not_before"] = "2024-02-30"
+    if _, err := ValidateReport(writeSigned(t, lic), NewKeyRing(&testSigner.PublicKey)); err == nil || !strings.Contains(err.Error(), `not_before "2024-02-30"`) {
+        t.Fatal(err)
+    }
+    delete(lic, "not_before")
+    if _, err := ValidateReport(writeSigned(t, lic), NewKeyRing(&testSigner.PublicKey)); err != nil {
+        t.Fatal(err)
+    }
+}
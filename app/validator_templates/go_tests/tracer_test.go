@@ -0,0 +1,67 @@
+package main
+
+import (
+    "context"
+    "crypto/rand"
+    "crypto/rsa"
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+type recSpan struct {
+    name  string
+    attrs map[string]any
+    err   error
+    ended bool
+}
+
+func (s *recSpan) SetAttribute(k string, v any) { s.attrs[k] = v }
+func (s *recSpan) RecordError(err error)        { s.err = err }
+func (s *recSpan) End()                         { s.ended = true }
+
+type recTracer struct{ spans []*recSpan }
+
+func (t *recTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+    s := &recSpan{name: name, attrs: map[string]any{}}
+    t.spans = append(t.spans, s)
+    return ctx, s
+}
+
+func TestTracer(t *testing.T) {
+    srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.Write([]byte(`{"revoked":false}`)) }))
+    defer srv.Close()
+    tr := &recTracer{}
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now(), 30))
+    v, _ := NewValidator(WithKeyRing(ring), WithTracer(tr), WithRevocationURL(srv.URL), WithResultCache(NewResultCache(time.Minute)))
+    for range 2 {
+        if _, err := v.Validate(p); err != nil {
+            t.Fatal(err)
+        }
+    }
+    if len(tr.spans) != 2 {
+        t.Fatal(len(tr.spans))
+    }
+    s := tr.spans[0]
+    if s.name != ValidationSpanName || !s.ended || s.err != nil || s.attrs["outcome"] != OutcomeValid || s.attrs["license_id"] != "lic-1" || s.attrs["online_checked"] != true || s.attrs["cache_hit"] != false {
+        t.Fatal(s)
+    }
+    if s := tr.spans[1]; s.attrs["cache_hit"] != true || s.attrs["online_checked"] != false {
+        t.Fatal(s.attrs)
+    }
+    other, _ := rsa.GenerateKey(rand.Reader, 2048)
+    v, _ = NewValidator(WithKeyRing(NewKeyRing(&other.PublicKey)), WithTracer(tr))
+    if _, err := v.Validate(p); err == nil {
+        t.Fatal("accepted")
+    }
+    if s := tr.spans[2]; !errors.Is(s.err, ErrSignatureInvalid) || s.attrs["outcome"] != OutcomeBadSignature {
+        t.Fatal(s)
+    }
+    v, _ = NewValidator(WithKeyRing(ring), WithTracer(nil))
+    if _, err := v.Validate(p); err != nil {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,25 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestTrial(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 14)
+    lic["trial"] = true
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    r, err := ValidateReport(p, ring, WithClock(&fixedClock{issued.Add(9*24*time.Hour + time.Hour)}))
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !r.IsTrial() || r.TrialDaysRemaining() != 5 {
+        t.Fatal(r.TrialDaysRemaining())
+    }
+    r, _ = ValidateReport(writeSigned(t, defaultLicense(issued, 14)), ring, WithClock(&fixedClock{issued}))
+    if r.IsTrial() || r.TrialDaysRemaining() != 0 {
+        t.Fatal()
+    }
+}
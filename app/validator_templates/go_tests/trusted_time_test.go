@@ -0,0 +1,40 @@
+package main
+
+import (
+    "errors"
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestTrustedTime(t *testing.T) {
+    issued := time.Now().AddDate(0, 0, -40)
+    p := writeSigned(t, defaultLicense(issued, 30)) // expired 10 days ago
+    ring := NewKeyRing(&testSigner.PublicKey)
+    tampered := &fixedClock{issued.AddDate(0, 0, 1)}
+    if _, err := ValidateReport(p, ring, WithClock(tampered)); err != nil {
+        t.Fatal("tampered clock should pass locally", err)
+    }
+    srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+    defer srv.Close()
+    opts := []Option{WithClock(tampered), WithTrustedTimeURL(srv.URL), WithHTTPClient(srv.Client())}
+    if _, err := ValidateReport(p, ring, opts...); !errors.Is(err, ErrLicenseExpired) {
+        t.Fatal(err)
+    }
+    fresh := writeSigned(t, defaultLicense(time.Now(), 30))
+    r, err := ValidateReport(fresh, ring, opts...)
+    if err != nil || time.Since(r.CheckedAt) > 5*time.Second {
+        t.Fatal(r, err)
+    }
+    srv.Close()
+    if _, err := ValidateReport(fresh, ring, opts...); !errors.Is(err, ErrTrustedTime) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(fresh, ring, append(opts, WithOfflinePolicy(FailOpen))...); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithTrustedTimeURL("http://example.com")); err == nil {
+        t.Fatal("http accepted")
+    }
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+type productClaims struct {
+    LicensePayload
+    Region  string `json:"region"`
+    Payload struct {
+        Plan string `json:"plan"`
+    } `json:"payload"`
+}
+
+func TestTypedClaims(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["region"] = "eu"
+    p := writeSigned(t, lic)
+    c, err := ValidateAs[productClaims](p, pubB64(t, &testSigner.PublicKey))
+    if err != nil || c.Region != "eu" || c.Payload.Plan != "pro" || c.ID != "lic-1" {
+        t.Fatal(err, c)
+    }
+    lic = defaultLicense(time.Now().AddDate(0, 0, -40), 30)
+    if _, err := ValidateAs[productClaims](writeSigned(t, lic), pubB64(t, &testSigner.PublicKey)); err == nil {
+        t.Fatal("expired accepted")
+    }
+}
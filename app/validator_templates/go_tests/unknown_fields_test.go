@@ -0,0 +1,29 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestUnknownFields(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    lic := defaultLicense(time.Now(), 30)
+    lic["payload"] = map[string]any{"anything": 1}
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithStrictFields()); err != nil {
+        t.Fatal(err)
+    }
+    lic["unexpected"] = true
+    p := writeSigned(t, lic)
+    if _, err := ValidateReport(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(p, ring, WithStrictFields()); !errors.Is(err, ErrUnknownField) {
+        t.Fatal(err)
+    }
+    delete(lic, "unexpected")
+    lic["licensee"] = map[string]any{"name": "a", "ssn": "x"}
+    if _, err := ValidateReport(writeSigned(t, lic), ring, WithStrictFields()); !errors.Is(err, ErrUnknownField) {
+        t.Fatal(err)
+    }
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+    "encoding/base64"
+    "encoding/json"
+    "errors"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestURLToken(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lb, _ := json.Marshal(lic)
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": pssSign(t, testSigner, lb)})
+    key := pubB64(t, &testSigner.PublicKey)
+    for _, tok := range []string{base64.RawURLEncoding.EncodeToString(data), base64.URLEncoding.EncodeToString(data), base64.StdEncoding.EncodeToString(data)} {
+        p, err := ValidateToken(tok, key)
+        if err != nil || p.ID != "lic-1" {
+            t.Fatal(tok, err)
+        }
+    }
+    tok := base64.RawURLEncoding.EncodeToString(data)
+    bad := tok[:20] + "!" + tok[21:]
+    if _, err := ValidateToken(bad, key); !errors.Is(err, ErrMalformedLicense) {
+        t.Fatal(err)
+    }
+    flipped := []byte(strings.Replace(string(data), `"pro"`, `"ent"`, 1))
+    if _, err := ValidateToken(base64.RawURLEncoding.EncodeToString(flipped), key); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateToken(tok, key, WithMaxLicenseSize(10)); !errors.Is(err, ErrLicenseTooLarge) {
+        t.Fatal(err)
+    }
+}
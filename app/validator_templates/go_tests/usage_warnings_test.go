@@ -0,0 +1,26 @@
+package main
+
+import (
+    "testing"
+    "time"
+)
+
+func TestUsageWarnings(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["max_seats"] = 100
+    lic["quotas"] = map[string]int{"projects": 10}
+    p := writeSigned(t, lic)
+    ring := NewKeyRing(&testSigner.PublicKey)
+    r, err := ValidateReport(p, ring, WithActiveSeats(89), WithSeatWarningThreshold(0.9))
+    if err != nil || len(r.UsageWarnings) != 0 {
+        t.Fatal(err, r.UsageWarnings)
+    }
+    r, err = ValidateReport(p, ring, WithActiveSeats(91), WithSeatWarningThreshold(0.9),
+        WithQuotaUsage(map[string]int{"projects": 9, "other": 1000}), WithQuotaWarningThreshold(0.9))
+    if err != nil || len(r.UsageWarnings) != 2 || r.UsageWarnings[0] != (UsageWarning{"seats", 91, 100}) || r.UsageWarnings[1].Resource != "quotas.projects" {
+        t.Fatal(err, r.UsageWarnings)
+    }
+    if _, err := NewValidator(WithSeatWarningThreshold(1.5)); err == nil {
+        t.Fatal("bad threshold accepted")
+    }
+}
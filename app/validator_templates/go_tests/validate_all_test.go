@@ -0,0 +1,42 @@
+package main
+
+import (
+    "context"
+    "testing"
+    "time"
+)
+
+func TestValidateAllProducts(t *testing.T) {
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    lic := defaultLicense(issued, 30)
+    lic["features"] = map[string]any{"base": map[string]any{"enabled": true}}
+    lic["products"] = []any{
+        map[string]any{"id": "a"},
+        map[string]any{"id": "b", "expires_at": issued.AddDate(0, 0, 20).Format(time.RFC3339), "features": map[string]any{"x": map[string]any{"enabled": true}}},
+        map[string]any{"id": "c", "expires_at": issued.AddDate(0, 0, 10).Format(time.RFC3339)},
+    }
+    p := writeSigned(t, lic)
+    clk := &fixedClock{issued.AddDate(0, 0, 21)}
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(clk), WithGracePeriod(72*time.Hour), WithProduct("c"))
+    states, err := v.ValidateAll(context.Background(), p)
+    if err != nil || len(states) != 3 {
+        t.Fatal(states, err)
+    }
+    if states[0].State != StateValid || states[1].State != StateInGrace || states[2].State != StateExpired {
+        t.Fatal(states)
+    }
+    if _, ok := states[1].Features["x"]; !ok || len(states[1].Features) != 2 || len(states[0].Features) != 1 {
+        t.Fatal(states[1].Features)
+    }
+    if states[1].GraceRemaining != 48*time.Hour {
+        t.Fatal(states[1].GraceRemaining)
+    }
+    v, _ = NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(&fixedClock{issued.AddDate(0, 0, 50)}))
+    states, err = v.ValidateAll(context.Background(), p)
+    if err != nil || len(states) != 3 || states[0].State != StateExpired || !states[0].ExpiresAt.Equal(issued.AddDate(0, 0, 30)) {
+        t.Fatal(states, err)
+    }
+    if _, err := ValidateAll(writeRaw(t, []byte("{}")), pubB64(t, &testSigner.PublicKey)); err == nil {
+        t.Fatal("bad license")
+    }
+}
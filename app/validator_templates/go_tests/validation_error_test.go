@@ -0,0 +1,26 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "path/filepath"
+    "testing"
+    "time"
+)
+
+func TestValidationErrorReasons(t *testing.T) {
+    key := pubB64(t, &testSigner.PublicKey)
+    p, err := Validate(writeSigned(t, defaultLicense(time.Now().Add(-time.Hour), 30)), key)
+    if err != nil || p.ID != "lic-1" {
+        t.Fatalf("valid: %v %+v", err, p)
+    }
+    _, err = Validate(filepath.Join(t.TempDir(), "nope"), key)
+    var ve *ValidationError
+    if !errors.As(err, &ve) || ve.Reason != ReasonNotFound || !errors.Is(err, os.ErrNotExist) {
+        t.Fatalf("missing: %v", err)
+    }
+    _, err = Validate(writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -40), 30)), key)
+    if !errors.As(err, &ve) || ve.Reason != ReasonExpired {
+        t.Fatalf("expired: %v", err)
+    }
+}
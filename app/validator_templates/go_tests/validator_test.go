@@ -0,0 +1,27 @@
+package main
+
+import (
+    "errors"
+    "testing"
+    "time"
+)
+
+func TestValidatorOptions(t *testing.T) {
+    if _, err := NewValidator(WithPublicKeys("garbage!")); !errors.Is(err, ErrUnsupportedKey) {
+        t.Fatal(err)
+    }
+    issued := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+    v, err := NewValidator(WithPublicKeys(pubB64(t, &testSigner.PublicKey)), WithClock(&fixedClock{issued.AddDate(0, 0, 31)}), WithGracePeriod(48*time.Hour))
+    if err != nil {
+        t.Fatal(err)
+    }
+    p := writeSigned(t, defaultLicense(issued, 30))
+    for i := 0; i < 2; i++ {
+        if r, err := v.Validate(p); !errors.Is(err, ErrInGracePeriod) || !r.InGrace {
+            t.Fatal(err)
+        }
+    }
+    if _, err := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey))); err != nil {
+        t.Fatal(err)
+    }
+}
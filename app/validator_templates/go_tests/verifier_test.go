@@ -0,0 +1,67 @@
+package main
+
+import (
+    "bytes"
+    "crypto"
+    "crypto/sha256"
+    "encoding/base64"
+    "errors"
+    "testing"
+    "time"
+)
+
+type stubKey struct{ secret []byte }
+
+func stubSign(k stubKey, msg []byte) string {
+    h := sha256.Sum256(append(append([]byte{}, k.secret...), msg...))
+    return base64.StdEncoding.EncodeToString(h[:])
+}
+
+func TestCustomVerifier(t *testing.T) {
+    key := stubKey{[]byte("s3cret")}
+    calls := 0
+    v := VerifierFunc(func(msg, sig []byte, k crypto.PublicKey) error {
+        calls++
+        sk, ok := k.(stubKey)
+        if !ok {
+            return errors.New("wrong key type")
+        }
+        want, _ := base64.StdEncoding.DecodeString(stubSign(sk, msg))
+        if !bytes.Equal(want, sig) {
+            return errors.New("stub: bad signature")
+        }
+        return nil
+    })
+    ring := NewKeyRing(key)
+    good := writeEnvelope(t, defaultLicense(time.Now(), 30), func(b []byte) string { return stubSign(key, b) }, map[string]any{"alg": "X-STUB"})
+    if _, err := ValidateReport(good, ring, WithVerifier("X-STUB", v), WithAllowedAlgorithms("X-STUB")); err != nil {
+        t.Fatal(err)
+    }
+    if calls != 1 {
+        t.Fatal(calls)
+    }
+    bad := writeEnvelope(t, defaultLicense(time.Now(), 30), func(b []byte) string { return stubSign(stubKey{[]byte("x")}, b) }, map[string]any{"alg": "X-STUB"})
+    if _, err := ValidateReport(bad, ring, WithVerifier("X-STUB", v), WithAllowedAlgorithms("X-STUB")); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    if _, err := ValidateReport(good, ring); !errors.Is(err, ErrUnsupportedAlgorithm) {
+        t.Fatal(err)
+    }
+    if _, err := NewValidator(WithVerifier("none", v)); err == nil {
+        t.Fatal("none accepted")
+    }
+    // Built-ins still work alongside a custom verifier.
+    p := writeEnvelope(t, defaultLicense(time.Now(), 30), func(b []byte) string { return pssSign(t, testSigner, b) }, map[string]any{"alg": "PS256"})
+    if _, err := ValidateReport(p, NewKeyRing(&testSigner.PublicKey), WithVerifier("X-STUB", v), WithAllowedAlgorithms("X-STUB", AlgPS256)); err != nil {
+        t.Fatal(err)
+    }
+}
+
+func TestCustomVerifierNeedsAllowlist(t *testing.T) {
+    key := stubKey{[]byte("k")}
+    v := VerifierFunc(func(message, sig []byte, pub crypto.PublicKey) error { return nil })
+    good := writeEnvelope(t, defaultLicense(time.Now(), 30), func(b []byte) string { return stubSign(key, b) }, map[string]any{"alg": "X-STUB"})
+    if _, err := ValidateReport(good, NewKeyRing(key), WithVerifier("X-STUB", v)); !errors.Is(err, ErrAlgorithmNotAllowed) {
+        t.Fatal(err)
+    }
+}
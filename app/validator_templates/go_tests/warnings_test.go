@@ -0,0 +1,37 @@
+package main
+
+import (
+    "net/http"
+    "net/http/httptest"
+    "testing"
+    "time"
+)
+
+func TestWarnings(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    p := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -28), 30))
+    r, err := ValidateReport(p, ring, WithExpiryWarning(7*24*time.Hour))
+    if err != nil || len(r.Warnings) != 1 || r.Warnings[0].Code != WarningExpiringSoon || r.Warnings[0].Message == "" {
+        t.Fatal(err, r.Warnings)
+    }
+    if r, err := ValidateReport(p, ring); err != nil || len(r.Warnings) != 0 {
+        t.Fatal(err, r.Warnings)
+    }
+    lic := defaultLicense(time.Now(), 30)
+    lic["max_seats"] = 10
+    dead := httptest.NewServer(http.NotFoundHandler())
+    dead.Close()
+    pending := NewKeyRing(&testSigner.PublicKey)
+    pending.AddEntry("", KeyEntry{Key: &testSigner.PublicKey, NotBefore: time.Now().Add(time.Hour)})
+    r, err = ValidateReport(writeSigned(t, lic), pending, WithActiveSeats(9), WithSeatWarningThreshold(0.8), WithRevocationURL(dead.URL), WithOfflinePolicy(FailOpen))
+    if err != nil {
+        t.Fatal(err)
+    }
+    codes := map[WarningCode]bool{}
+    for _, w := range r.Warnings {
+        codes[w.Code] = true
+    }
+    if len(r.Warnings) != 3 || !codes[WarningSeatLimit] || !codes[WarningOnlineCheckSkipped] || !codes[WarningKeyRotationPending] {
+        t.Fatal(r.Warnings)
+    }
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+    "context"
+    "os"
+    "slices"
+    "testing"
+    "time"
+)
+
+func TestWatchDelta(t *testing.T) {
+    lic := defaultLicense(time.Now(), 30)
+    lic["features"] = map[string]any{"export": true, "sso": true, "beta": false}
+    p := writeSigned(t, lic)
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)))
+    ctx, cancel := context.WithCancel(context.Background())
+    defer cancel()
+    ev := v.Watch(ctx, p, 5*time.Millisecond)
+    if e := <-ev; e.State != StateValid || e.Added != nil || e.Removed != nil {
+        t.Fatal(e)
+    }
+    lic["features"] = map[string]any{"export": true, "beta": true}
+    swapped, _ := os.ReadFile(writeSigned(t, lic))
+    os.WriteFile(p, swapped, 0o600)
+    e := <-ev
+    if e.State != StateValid || !slices.Equal(e.Added, []string{"beta"}) || !slices.Equal(e.Removed, []string{"sso"}) {
+        t.Fatal(e.State, e.Added, e.Removed)
+    }
+    select {
+    case e := <-ev:
+        t.Fatal("unexpected event", e)
+    case <-time.After(50 * time.Millisecond):
+    }
+}
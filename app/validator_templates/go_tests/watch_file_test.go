@@ -0,0 +1,46 @@
+package main
+
+import (
+    "context"
+    "os"
+    "testing"
+    "time"
+)
+
+func TestWatchFile(t *testing.T) {
+    expired := writeSigned(t, defaultLicense(time.Now().AddDate(0, 0, -60), 30))
+    fresh, _ := os.ReadFile(writeSigned(t, defaultLicense(time.Now(), 30)))
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)))
+    ctx, cancel := context.WithCancel(context.Background())
+    ch, err := v.WatchFile(ctx, expired)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if r := <-ch; len(r.Failed()) == 0 {
+        t.Fatal("expired reported valid")
+    }
+    // Two quick writes should yield one report.
+    os.WriteFile(expired, fresh[:10], 0o600)
+    time.Sleep(20 * time.Millisecond)
+    os.WriteFile(expired, fresh, 0o600)
+    select {
+    case r := <-ch:
+        if len(r.Failed()) != 0 {
+            t.Fatal(r.Failed())
+        }
+    case <-time.After(3 * time.Second):
+        t.Fatal("no report")
+    }
+    select {
+    case r := <-ch:
+        t.Fatal("extra report", r.Failed())
+    case <-time.After(600 * time.Millisecond):
+    }
+    cancel()
+    if _, ok := <-ch; ok {
+        t.Fatal("not closed")
+    }
+    if _, err := v.WatchFile(context.Background(), expired+".missing"); err == nil {
+        t.Fatal("missing")
+    }
+}
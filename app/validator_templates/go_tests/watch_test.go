@@ -0,0 +1,46 @@
+package main
+
+import (
+    "context"
+    "runtime"
+    "sync"
+    "testing"
+    "time"
+)
+
+type movingClock struct {
+    mu sync.Mutex
+    t  time.Time
+}
+
+func (c *movingClock) Now() time.Time { c.mu.Lock(); defer c.mu.Unlock(); return c.t }
+func (c *movingClock) set(t time.Time) { c.mu.Lock(); c.t = t; c.mu.Unlock() }
+
+func TestWatch(t *testing.T) {
+    before := runtime.NumGoroutine()
+    issued := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+    p := writeSigned(t, defaultLicense(issued, 30))
+    clk := &movingClock{t: issued.AddDate(0, 0, 29)}
+    v, _ := NewValidator(WithKeyRing(NewKeyRing(&testSigner.PublicKey)), WithClock(clk), WithGracePeriod(48*time.Hour))
+    ctx, cancel := context.WithCancel(context.Background())
+    ev := v.Watch(ctx, p, 5*time.Millisecond)
+    if e := <-ev; e.State != StateValid {
+        t.Fatal(e)
+    }
+    time.Sleep(30 * time.Millisecond)
+    clk.set(issued.AddDate(0, 0, 31))
+    if e := <-ev; e.State != StateInGrace {
+        t.Fatal(e)
+    }
+    clk.set(issued.AddDate(0, 0, 40))
+    if e := <-ev; e.State != StateExpired {
+        t.Fatal(e)
+    }
+    cancel()
+    for range ev {
+    }
+    time.Sleep(20 * time.Millisecond)
+    if runtime.NumGoroutine() > before {
+        t.Fatal("leak", runtime.NumGoroutine(), before)
+    }
+}
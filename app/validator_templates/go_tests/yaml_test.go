@@ -0,0 +1,99 @@
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+    "time"
+)
+
+func TestYAMLMatchesJSON(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    issued := time.Now().UTC().Truncate(time.Second).Format(time.RFC3339)
+    lic := map[string]any{"id": "lic-y", "tenant_id": "ten-1", "issued_at": issued, "validity_days": 30,
+        "allowed_regions": []string{"a", "b"}, "features": map[string]any{"x": true}, "payload": map[string]any{"plan": "pro <x>", "seats": 5}}
+    lb, _ := json.Marshal(lic)
+    canon, _ := CanonicalJSON(lb)
+    sig := pssSign(t, testSigner, canon)
+    data, _ := json.Marshal(map[string]any{"license": json.RawMessage(lb), "signature": sig, "canonical": true})
+    jr, err := ValidateReport(writeRaw(t, data), ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    yaml := `---
+# internal trial
+license:
+  id: lic-y   # comment
+  tenant_id: "ten-1"
+  issued_at: ` + issued + `
+  validity_days: 30
+  allowed_regions:
+  - a
+  - 'b'
+  features:
+    x: true
+  payload:
+    plan: "pro <x>"
+    seats: 5
+signature: ` + sig + "\n"
+    p := filepath.Join(t.TempDir(), "license.yaml")
+    os.WriteFile(p, []byte(yaml), 0o600)
+    yr, err := ValidateReport(p, ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if !yr.ExpiresAt.Equal(jr.ExpiresAt) || yr.Payload.ID != "lic-y" || !yr.HasFeature("x") || !reflect.DeepEqual(yr.Payload, jr.Payload) {
+        t.Fatalf("%+v\n%+v", yr.Payload, jr.Payload)
+    }
+    tampered := strings.Replace(yaml, "seats: 5", "seats: 50", 1)
+    os.WriteFile(p, []byte(tampered), 0o600)
+    if _, err := ValidateReport(p, ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+    flow := strings.Replace(yaml, "  allowed_regions:\n  - a\n  - 'b'\n", "  allowed_regions: [a, \"b\"]\n", 1)
+    os.WriteFile(p, []byte(flow), 0o600)
+    if _, err := ValidateReport(p, ring); err != nil {
+        t.Fatal(err)
+    }
+    for _, bad := range []string{"a: &x 1\n", "a: 1\na: 2\n", "a:\n  b: 1\n c: 2\n", "a: |\n  x\n"} {
+        if _, err := parseYAMLLicense([]byte(bad)); !errors.Is(err, ErrMalformedLicense) {
+            t.Fatal(bad, err)
+        }
+    }
+}
+
+func TestYAMLVersion2(t *testing.T) {
+    ring := NewKeyRing(&testSigner.PublicKey)
+    payload := LicensePayload{ID: "lic-y2", ExpiresAt: time.Now().Add(time.Hour).UTC().Format(time.RFC3339), IssuedAt: time.Now().UTC().Format(time.RFC3339)}
+    d, err := Sign(payload, testSigner, WithFormatVersion(2))
+    if err != nil {
+        t.Fatal(err)
+    }
+    yaml := `version: 2
+alg: ` + d.Alg + `
+license:
+  id: lic-y2
+  tenant_id: ""
+  issued_at: ` + payload.IssuedAt + `
+  validity_days: 0
+  expires_at: ` + payload.ExpiresAt + `
+  payload: null
+signature: ` + d.Signature + "\n"
+    p := filepath.Join(t.TempDir(), "license.yaml")
+    os.WriteFile(p, []byte(yaml), 0o600)
+    r, err := ValidateReport(p, ring)
+    if err != nil {
+        t.Fatal(err)
+    }
+    if r.Payload.ID != "lic-y2" {
+        t.Fatal(r.Payload.ID)
+    }
+    os.WriteFile(p, []byte(strings.Replace(yaml, "version: 2", "version: 2\ncanonical: true", 1)), 0o600)
+    if _, err := ValidateReport(p, ring); !errors.Is(err, ErrSignatureInvalid) {
+        t.Fatal(err)
+    }
+}
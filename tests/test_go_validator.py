@@ -0,0 +1,66 @@
+"""Tests for the Go validator template.
+
+The Go tests in app/validator_templates/go_tests are compiled against the
+template rendered the way generate_validator_code serves it, with a fresh
+master key, in a scratch module. They need a Go 1.24 or later toolchain and
+are skipped without one.
+"""
+
+import os
+import shutil
+import subprocess
+import tempfile
+import unittest
+from pathlib import Path
+
+from app.crypto_utils import generate_rsa_keypair
+
+TEMPLATES = Path(__file__).resolve().parent.parent / "app" / "validator_templates"
+
+# Nothing listens here, so a test that reaches for the base URL fails fast.
+BASE_URL = "http://127.0.0.1:1"
+
+
+@unittest.skipUnless(shutil.which("go"), "Go toolchain not installed")
+class GoValidatorTests(unittest.TestCase):
+    @classmethod
+    def setUpClass(cls):
+        cls.tmp = tempfile.TemporaryDirectory()
+        cls.module = Path(cls.tmp.name)
+        _, master_private_key = generate_rsa_keypair()
+        code = (TEMPLATES / "go.template").read_text()
+        code = code.replace("{master_private_key}", master_private_key).replace("{base_url}", BASE_URL)
+        (cls.module / "main.go").write_text(code)
+        (cls.module / "go.mod").write_text("module licensevalidator\n\ngo 1.24\n")
+        for test in (TEMPLATES / "go_tests").glob("*_test.go"):
+            shutil.copy(test, cls.module)
+
+    @classmethod
+    def tearDownClass(cls):
+        cls.tmp.cleanup()
+
+    def go(self, *args, **env):
+        result = subprocess.run(
+            ["go", *args],
+            cwd=self.module,
+            env={**os.environ, **env},
+            capture_output=True,
+            text=True,
+        )
+        if result.returncode != 0:
+            self.fail(f"go {' '.join(args)} failed:\n{result.stdout}{result.stderr}")
+
+    def test_vet(self):
+        self.go("vet", "./...")
+
+    def test_builds_for_windows_and_darwin(self):
+        for goos in ("windows", "darwin"):
+            with self.subTest(goos=goos):
+                self.go("build", "-o", os.devnull, ".", GOOS=goos)
+
+    def test_go_tests_pass_under_race_detector(self):
+        self.go("test", "-race", "-count=1", "./...")
+
+
+if __name__ == "__main__":
+    unittest.main()